@@ -0,0 +1,358 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/fakeproviders"
+	"github.com/tamarakaufler/travel-article-headings/internal/grpcapi"
+	pb "github.com/tamarakaufler/travel-article-headings/internal/grpcapi/headingsv1"
+	"github.com/tamarakaufler/travel-article-headings/internal/server"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+	"github.com/tamarakaufler/travel-article-headings/pkg/headings"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+var (
+	localeUsage = "heading language: " + strings.Join(headings.Locales(), ", ") + " (overrides LOCALE)"
+	toneUsage   = "editorial tone of the headings: " + strings.Join(headings.Tones(), ", ") +
+		" or auto (overrides TONE, the article sidecar metadata overrides the tone)"
+)
+
+const (
+	topUsage      = "number of the best scored headings suggested, 0 for all (overrides HEADINGS_TOP)"
+	minScoreUsage = "least score (0-1) of the suggested headings (overrides HEADINGS_MIN_SCORE)"
+	profilesUsage = "comma separated output profiles: seo-title, og-title, tweet, newsletter-subject (overrides PROFILES)"
+)
+
+func main() {
+	// the fake providers do not need any configuration
+	if len(os.Args) > 1 && os.Args[1] == "fake-providers" {
+		fakeProviders(os.Args[2:])
+		return
+	}
+
+	cfg, err := conf.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		serve(cfg, os.Args[2:])
+		return
+	}
+
+	dir := flag.String("dir", "", "directory with article csv files (overrides TRAVEL_ARTICLES_DIR)")
+	loc := flag.String("locale", cfg.Locale, localeUsage)
+	top := flag.Int("top", cfg.HeadingsTop, topUsage)
+	minScore := flag.Float64("min-score", cfg.HeadingsMinScore, minScoreUsage)
+	profileNames := flag.String("profiles", cfg.Profiles, profilesUsage)
+	headingTone := flag.String("tone", cfg.Tone, toneUsage)
+	flag.Parse()
+
+	if *dir == "" {
+		*dir = cfg.Directory
+	}
+	profiles, err := headings.ParseProfiles(*profileNames)
+	if err != nil {
+		log.Fatal(err)
+	}
+	baseline, err := placesBaseline(cfg.PlacesBaseline)
+	if err != nil {
+		log.Fatal(err)
+	}
+	seasons, err := seasonTable(cfg.SeasonCalendar, cfg.SeasonTable)
+	if err != nil {
+		log.Fatal(err)
+	}
+	holidays, err := holidayCalendar(cfg.HolidayCalendar)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ps, err := headings.ProvidersFromEnv(*loc)
+	if err != nil {
+		log.Fatal(err)
+	}
+	run(context.Background(), *dir, ps,
+		headings.WithLocale(*loc),
+		headings.WithSegmentation(cfg.TripMaxGap, cfg.TripMaxDistance),
+		headings.WithClustering(cfg.ClusterEps, cfg.ClusterMinPoints),
+		headings.WithWeighting(headings.Weighting(cfg.RankingWeighting), cfg.RankingMaxDwell),
+		headings.WithSeasons(headings.SeasonCalendar(cfg.SeasonCalendar), seasons),
+		headings.WithHolidays(holidays),
+		headings.WithTop(*top, *minScore),
+		headings.WithScoring(headings.ScoreWeights{
+			Specificity: cfg.ScoreSpecificity,
+			Confidence:  cfg.ScoreConfidence,
+			Length:      cfg.ScoreLength,
+			Readability: cfg.ScoreReadability,
+			Redundancy:  cfg.ScoreRedundancy,
+		}),
+		headings.WithDiversity(cfg.DiversityOversample, cfg.DiversityLambda),
+		headings.WithProfiles(profiles...),
+		headings.WithPlaces(cfg.PlacesDistinctive, baseline),
+		headings.WithTone(*headingTone),
+		// the articles of the run do not reuse the same phrasing.
+		headings.WithPhraseBook(headings.NewPhraseBook()),
+	)
+}
+
+// seasonTable reads the custom seasons table file, if any. The custom calendar requires the table.
+func seasonTable(calendar, path string) (map[string]map[time.Month]string, error) {
+	if path == "" {
+		if headings.SeasonCalendar(calendar) == headings.CustomSeasons {
+			return nil, errors.New("custom season calendar requires a season table (SEASON_TABLE)")
+		}
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return headings.ReadSeasonTable(f)
+}
+
+// holidayCalendar reads the holiday calendar file extending the bundled one, if any.
+func holidayCalendar(path string) (*headings.HolidayCalendar, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return headings.ReadHolidayCalendar(f)
+}
+
+// placesBaseline reads the places of interest baseline file extending the bundled one, if any.
+func placesBaseline(path string) (*headings.PlacesBaseline, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return headings.ReadPlacesBaseline(f)
+}
+
+// run processes the csv files in the directory concurrently and presents the heading
+// suggestions for each file/article as soon as they are available. The article sidecar
+// metadata files (eg article1.meta.json of article1.csv) are not processed as articles.
+func run(ctx context.Context, dir string, ps headings.Providers, opts ...headings.Option) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		log.Fatalf("failure to get articles: %s", err)
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, f := range files {
+		if headings.IsMetadata(f.Name()) {
+			continue
+		}
+		wg.Add(1)
+		go func(fp string) {
+			defer wg.Done()
+
+			suggest(ctx, fp, ps, opts...)
+		}(filepath.Join(dir, f.Name()))
+	}
+	wg.Wait()
+
+	log.Print("FINISHED 🎉\n")
+}
+
+func suggest(ctx context.Context, fp string, ps headings.Providers, opts ...headings.Option) {
+	log.Printf("Processing article: %s\n", fp)
+
+	f, err := os.Open(fp)
+	if err != nil {
+		log.Fatalf("failure to get photos %s", err)
+	}
+	defer f.Close()
+
+	photos, err := headings.ReadPhotos(f)
+	if err != nil {
+		log.Fatalf("failure to get photos %s", err)
+	}
+
+	articleTone, err := headings.ArticleTone(fp)
+	if err != nil {
+		log.Fatalf("failure to get article metadata %s", err)
+	}
+
+	opts = append([]headings.Option{
+		headings.WithProviders(ps),
+		headings.WithLogger(log.Default()),
+	}, opts...)
+	if articleTone != "" {
+		opts = append(opts, headings.WithTone(articleTone))
+	}
+	res, err := headings.Suggest(ctx, photos, opts...)
+	if errors.Is(err, headings.ErrUnauthorized) {
+		log.Fatalf("%s: %s.\nThe article processing was aborted.\n", fp, err)
+	}
+	if err != nil {
+		log.Printf("%s: %s.\nNo heading suggestions could be made.\n", fp, err)
+		log.Printf("locations: %d, weather data: %d, poi %d\n\n",
+			res.Summary.Locations, res.Summary.Weathers, res.Summary.Pois)
+		return
+	}
+	present(fp, res)
+}
+
+// present prints the suggested headings and the headings fitted to the output profiles, if any.
+func present(fp string, res headings.Result) {
+	fmt.Printf("---------------------------------------\n")
+	log.Printf("%s\n\n", fp)
+	for _, h := range res.Headings {
+		fmt.Printf("\t%s\n", h)
+	}
+	fmt.Printf("---------------------------------------\n")
+
+	if len(res.Profiled) == 0 {
+		return
+	}
+	fmt.Printf("---------------------------------------\n")
+	log.Printf("%s profiles\n\n", fp)
+	for _, ph := range res.Profiled {
+		switch {
+		case ph.Dropped:
+			fmt.Printf("\t[%s] dropped: %s\n", ph.Profile, ph.Original)
+		case ph.Compliant:
+			fmt.Printf("\t[%s] %s (/%s)\n", ph.Profile, ph.Heading, ph.Slug)
+		default:
+			fmt.Printf("\t[%s] %s (/%s) - %s\n", ph.Profile, ph.Heading, ph.Slug, strings.Join(ph.Issues, ", "))
+		}
+	}
+	fmt.Printf("---------------------------------------\n")
+}
+
+// serve runs the HTTP and gRPC API servers until interrupted.
+func serve(cfg conf.Setup, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", cfg.ServerAddr, "HTTP API address (overrides SERVER_ADDR)")
+	grpcAddr := fs.String("grpc-addr", cfg.GRPCAddr, "gRPC API address (overrides GRPC_ADDR)")
+	fs.StringVar(&cfg.Locale, "locale", cfg.Locale, localeUsage)
+	fs.IntVar(&cfg.HeadingsTop, "top", cfg.HeadingsTop, topUsage)
+	fs.Float64Var(&cfg.HeadingsMinScore, "min-score", cfg.HeadingsMinScore, minScoreUsage)
+	fs.StringVar(&cfg.Profiles, "profiles", cfg.Profiles, profilesUsage)
+	fs.StringVar(&cfg.Tone, "tone", cfg.Tone, toneUsage)
+	_ = fs.Parse(args)
+
+	as, err := service.New(cfg, "")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	s := server.New(as)
+	hs := &http.Server{
+		Addr:              *addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	lis, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	gs := grpc.NewServer()
+	pb.RegisterHeadingServiceServer(gs, grpcapi.New(as))
+
+	go func() {
+		log.Printf("Serving gRPC API on %s\n", *grpcAddr)
+		if err := gs.Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		s.SetReady(false)
+		gs.GracefulStop()
+
+		sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := hs.Shutdown(sctx); err != nil {
+			log.Printf("ERROR failure to shut down the server: %s\n", err)
+		}
+		if err := s.Shutdown(sctx); err != nil {
+			log.Printf("ERROR failure to finish the asynchronous jobs: %s\n", err)
+		}
+	}()
+
+	log.Printf("Serving HTTP API on %s\n", *addr)
+	if err := hs.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
+
+// fakeProviders runs the fake 3rd party providers until interrupted.
+func fakeProviders(args []string) {
+	fs := flag.NewFlagSet("fake-providers", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8081", "fake providers address")
+	latency := fs.Duration("latency", 0, "latency added to every response")
+	rateLimited := fs.Float64("rate-limited", 0, "fraction (0-1) of requests answered with 429/Too many requests")
+	errs := fs.Float64("errors", 0, "fraction (0-1) of requests answered with 500/Internal server error")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "seed of the fault injection")
+	_ = fs.Parse(args)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	hs := &http.Server{
+		Addr: *addr,
+		Handler: fakeproviders.New(fakeproviders.Config{
+			Latency:     *latency,
+			RateLimited: *rateLimited,
+			Errors:      *errs,
+			Seed:        *seed,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := hs.Shutdown(sctx); err != nil {
+			log.Printf("ERROR failure to shut down the server: %s\n", err)
+		}
+	}()
+
+	log.Printf("Serving fake providers on %s. Use them with:\n\t%s\n", *addr,
+		strings.Join(fakeproviders.Env("http://"+*addr), " \\\n\t"))
+	if err := hs.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
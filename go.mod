@@ -3,7 +3,10 @@ module github.com/tamarakaufler/travel-article-headings
 go 1.16
 
 require (
+	github.com/andybalholm/brotli v1.0.4
 	github.com/caarlos0/env/v6 v6.5.0
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.40.0
+	google.golang.org/protobuf v1.27.1
 )
@@ -2,23 +2,29 @@ package client
 
 import (
 	"context"
-	"fmt"
-	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
-	"sync"
+	"time"
 
 	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
 )
 
 type client struct {
-	URL    *url.URL
-	APIKey string
-	urlKey string
-
-	mu *sync.Mutex
+	URL      *url.URL
+	APIKey   string
+	urlKey   string
+	provider string
+
+	httpClient      *http.Client
+	maxResponseSize int64
+	// lang is the Accept-Language of the responses, en-US if not provided.
+	lang string
 }
 
+// HTTPProvider selects the real weather/places of interest clients (instead of the mocks).
+const HTTPProvider = "http"
+
 // Clients collect all the required clients.
 type Clients struct {
 	Addresses Addresses
@@ -26,19 +32,31 @@ type Clients struct {
 	POI       Poi
 }
 
+// Option customises the 3rd party clients.
+type Option func(*client)
+
+// WithTransport replaces the HTTP transport of the client, eg with a Recorder.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *client) {
+		hc := *c.httpClient
+		hc.Transport = rt
+		c.httpClient = &hc
+	}
+}
+
 // BuildClients ...
-func BuildClients(cfg conf.Setup) (Clients, error) {
-	addressesClient, err := NewAddressesClient(cfg)
+func BuildClients(cfg conf.Setup, opts ...Option) (Clients, error) {
+	addressesClient, err := NewAddressesClient(cfg, opts...)
 	if err != nil {
 		return Clients{}, err
 	}
 
-	weatherClient, err := NewWeatherClient(cfg)
+	weatherClient, err := NewWeatherClient(cfg, opts...)
 	if err != nil {
 		return Clients{}, err
 	}
 
-	poiClient, err := NewPoiClient(cfg)
+	poiClient, err := NewPoiClient(cfg, opts...)
 	if err != nil {
 		return Clients{}, err
 	}
@@ -50,12 +68,36 @@ func BuildClients(cfg conf.Setup) (Clients, error) {
 	}, nil
 }
 
-// MakeGetRequest will be used for all 3rd party requests.
-func (c client) MakeGetRequest(ctx context.Context, query map[string]string) ([]byte, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// NewHTTPClient creates an http.Client with connect/read timeouts and keep-alive
+// connection pooling. Each 3rd party client gets its own.
+func NewHTTPClient(cfg conf.Setup) *http.Client {
+	return &http.Client{
+		Timeout: cfg.HTTPTimeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   cfg.HTTPConnectTimeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   cfg.HTTPConnectTimeout,
+			ResponseHeaderTimeout: cfg.HTTPReadTimeout,
+			MaxIdleConns:          cfg.HTTPMaxIdleConns,
+			MaxIdleConnsPerHost:   cfg.HTTPMaxIdleConns,
+			IdleConnTimeout:       cfg.HTTPIdleConnTimeout,
+			// compressed responses are decoded in MakeGetRequest
+			DisableCompression: true,
+		},
+	}
+}
 
-	u := c.URL
+// MakeGetRequest will be used for all 3rd party requests. Failures are reported
+// as ProviderError.
+// Compressed responses (gzip, deflate, br) are decoded and the decoded body
+// size is capped.
+// The request URL is built from a copy of the base URL, so concurrent requests
+// do not share any state.
+func (c client) MakeGetRequest(ctx context.Context, query map[string]string) ([]byte, error) {
+	u := *c.URL
 	q := u.Query()
 	for k, v := range query {
 		q.Set(k, v)
@@ -63,46 +105,47 @@ func (c client) MakeGetRequest(ctx context.Context, query map[string]string) ([]
 	q.Set(c.urlKey, c.APIKey)
 	u.RawQuery = q.Encode()
 
-	req := &http.Request{
-		Method: "GET",
-		URL:    u,
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, c.requestError(&u, err)
 	}
-	req.Header = headers()
+	req.Header = headers(c.lang)
 
-	res, err := http.DefaultClient.Do(req)
+	hc := c.httpClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, c.requestError(&u, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != http.StatusOK {
-		if res.StatusCode == http.StatusTooManyRequests {
-			return nil, fmt.Errorf("client (%s) responds with too many requests error", c.URL.String())
-		}
-		return nil, fmt.Errorf("failure to retrieve photo location: HTTP status = %d", res.StatusCode)
+		return nil, c.statusError(&u, res.StatusCode)
 	}
 
-	data, err := ioutil.ReadAll(res.Body)
+	data, err := readBody(res.Body, res.Header.Get("Content-Encoding"), c.maxResponseSize)
 	if err != nil {
-		return nil, err
+		return nil, c.bodyError(&u, err)
 	}
 
 	return data, nil
 }
 
-func headers() map[string][]string {
+func headers(lang string) map[string][]string {
+	if lang == "" {
+		lang = "en-US"
+	}
 	return map[string][]string{
 		"Accept": {"application/json"},
-		// This header results in "invalid character '\x1f' looking for beginning of value"
-		// (https://github.com/influxdata/influxdb-go/issues/12)
-		//	"Accept-Encoding": {"gzip", "deflate", "br"}:
-		//					causes an error "invalid character '\x1f' looking for beginning of value".
-		//					The reason is that, if using this header, the 3rd party sends compressed
-		//					content, that would need to be uncompressed before unmarshalling.
+		// The 3rd party may send compressed content, that is uncompressed
+		// before unmarshalling (see readBody).
+		"Accept-Encoding": {acceptEncoding},
 		"Content-Type":    {"application/json; charset=UTF-8"},
 		"Connection":      {"keep-alive"},
 		"Cache-Control":   {"max-age=0"},
 		"User-Agent":      {"travel-article-headings"},
-		"Accept-Language": {"en-GB", "en-US"},
+		"Accept-Language": {lang},
 	}
 }
@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"os"
 	"reflect"
-	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -53,7 +52,8 @@ func Test_client_MakeGetRequest(t *testing.T) {
 				URL:    u,
 				APIKey: cs.HereAPIKey,
 				urlKey: "apiKey",
-				mu:     &sync.Mutex{},
+
+				httpClient: NewHTTPClient(cs),
 			}
 			got, err := c.MakeGetRequest(tt.args.ctx, tt.args.query)
 			if (err != nil) != tt.wantErr {
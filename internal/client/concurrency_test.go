@@ -0,0 +1,105 @@
+// +build unit_tests
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+)
+
+const locationResponse = `{"items": [{"address": {"countryName": "Italy", "city": "Sorrento"}}]}`
+
+// slowServer responds to every request after the provided delay.
+func slowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(locationResponse))
+	}))
+}
+
+func slowServerConf(url string) conf.Setup {
+	return conf.Setup{
+		HereURL:             url,
+		HereAPIKey:          apiKey,
+		HTTPConnectTimeout:  time.Second,
+		HTTPReadTimeout:     5 * time.Second,
+		HTTPMaxIdleConns:    50,
+		HTTPIdleConnTimeout: time.Minute,
+	}
+}
+
+func TestLocate_Concurrent(t *testing.T) {
+	const (
+		requests = 10
+		delay    = 200 * time.Millisecond
+	)
+
+	ts := slowServer(delay)
+	defer ts.Close()
+
+	ac, err := client.NewAddressesClient(slowServerConf(ts.URL))
+	require.NoError(t, err)
+
+	start := time.Now()
+	wg := sync.WaitGroup{}
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			loc, err := ac.Locate(context.Background(), pd)
+			if err == nil && loc.City != "Sorrento" {
+				t.Errorf("unexpected location %+v", loc)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	// serialised requests would take requests*delay
+	require.Less(t, int64(time.Since(start)), int64(requests*delay/2))
+}
+
+func TestLocate_ReadTimeout(t *testing.T) {
+	ts := slowServer(time.Second)
+	defer ts.Close()
+
+	cfg := slowServerConf(ts.URL)
+	cfg.HTTPReadTimeout = 50 * time.Millisecond
+	ac, err := client.NewAddressesClient(cfg)
+	require.NoError(t, err)
+
+	_, err = ac.Locate(context.Background(), pd)
+	require.Error(t, err)
+	require.ErrorIs(t, err, client.ErrProviderUnavailable)
+}
+
+func BenchmarkLocate_Parallel(b *testing.B) {
+	ts := slowServer(10 * time.Millisecond)
+	defer ts.Close()
+
+	ac, err := client.NewAddressesClient(slowServerConf(ts.URL))
+	require.NoError(b, err)
+
+	b.SetParallelism(10)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := ac.Locate(context.Background(), pd); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
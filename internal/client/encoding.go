@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/pkg/errors"
+)
+
+// acceptEncoding lists the content encodings the client can decode.
+const acceptEncoding = "gzip, deflate, br"
+
+// defaultMaxResponseSize is used when no response size cap is configured.
+const defaultMaxResponseSize = 10 << 20
+
+// readBody decodes the response body based on its Content-Encoding header.
+// The size cap applies to the decoded body.
+func readBody(r io.Reader, contentEncoding string, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxResponseSize
+	}
+
+	// Encodings are listed in the order they were applied.
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		dr, err := decoder(r, strings.ToLower(strings.TrimSpace(encodings[i])))
+		if err != nil {
+			return nil, err
+		}
+		defer dr.Close()
+		r = dr
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, errors.Wrapf(ErrResponseTooLarge, "response exceeds %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
+func decoder(r io.Reader, encoding string) (io.ReadCloser, error) {
+	switch encoding {
+	case "", "identity":
+		return ioutil.NopCloser(r), nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(r)
+	case "deflate":
+		return deflateReader(r)
+	case "br":
+		return ioutil.NopCloser(brotli.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// deflateReader handles zlib wrapped deflate data as required by the HTTP spec,
+// as well as raw deflate data sent by some servers.
+func deflateReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	// zlib header: compression method 8 and the header checksum
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
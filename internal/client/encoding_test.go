@@ -0,0 +1,158 @@
+// +build unit_tests
+
+package client_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+)
+
+const fixture = "response/here/hereRevgeocodeResponse.json"
+
+func TestLocate_CompressedResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		compress func(w io.Writer) io.WriteCloser
+	}{
+		{
+			name:     "gzip",
+			encoding: "gzip",
+			compress: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		},
+		{
+			name:     "deflate",
+			encoding: "deflate",
+			compress: func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		},
+		{
+			name:     "raw deflate",
+			encoding: "deflate",
+			compress: func(w io.Writer) io.WriteCloser {
+				fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+				return fw
+			},
+		},
+		{
+			name:     "brotli",
+			encoding: "br",
+			compress: func(w io.Writer) io.WriteCloser { return brotli.NewWriter(w) },
+		},
+		{
+			name:     "not compressed",
+			encoding: "",
+			compress: nopWriteCloser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := compressFixture(t, tt.compress)
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "gzip, deflate, br", r.Header.Get("Accept-Encoding"))
+
+				if tt.encoding != "" {
+					w.Header().Set("Content-Encoding", tt.encoding)
+				}
+				_, _ = w.Write(body)
+			}))
+			defer ts.Close()
+
+			ac, err := client.NewAddressesClient(conf.Setup{HereURL: ts.URL, HereAPIKey: apiKey})
+			require.NoError(t, err)
+
+			loc, err := ac.Locate(context.Background(), pd)
+			require.NoError(t, err)
+			require.Equal(t, "Italy", loc.Country)
+			require.Equal(t, "Sorrento", loc.City)
+		})
+	}
+}
+
+func TestLocate_CompressedResponseErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		encoding        string
+		body            []byte
+		maxResponseSize int64
+		want            error
+	}{
+		{
+			name:     "corrupted gzip",
+			encoding: "gzip",
+			body:     []byte("not really gzip"),
+			want:     client.ErrDecode,
+		},
+		{
+			name:     "unsupported encoding",
+			encoding: "compress",
+			body:     []byte(locationResponse),
+			want:     client.ErrDecode,
+		},
+		{
+			name:            "decoded response too large",
+			encoding:        "gzip",
+			body:            compressFixture(t, func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }),
+			maxResponseSize: 512,
+			want:            client.ErrResponseTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", tt.encoding)
+				_, _ = w.Write(tt.body)
+			}))
+			defer ts.Close()
+
+			ac, err := client.NewAddressesClient(conf.Setup{
+				HereURL:             ts.URL,
+				HereAPIKey:          apiKey,
+				HTTPMaxResponseSize: tt.maxResponseSize,
+			})
+			require.NoError(t, err)
+
+			_, err = ac.Locate(context.Background(), pd)
+			require.Error(t, err)
+			require.ErrorIs(t, err, tt.want)
+			require.False(t, strings.Contains(err.Error(), apiKey), "API key not redacted: %s", err)
+		})
+	}
+}
+
+func compressFixture(t *testing.T, compress func(w io.Writer) io.WriteCloser) []byte {
+	data, err := ioutil.ReadFile(fixture)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	w := compress(buf)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func nopWriteCloser(w io.Writer) io.WriteCloser {
+	return nopCloser{w}
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Kinds of provider failures. ProviderError matches them with errors.Is.
+var (
+	ErrRateLimited         = errors.New("rate limited")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrNotFound            = errors.New("not found")
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	ErrUnexpectedStatus    = errors.New("unexpected HTTP status")
+	ErrDecode              = errors.New("failure to decode response")
+	ErrResponseTooLarge    = errors.New("response too large")
+)
+
+// ProviderError is a failure of a 3rd party request.
+// The URL does not contain the API key.
+type ProviderError struct {
+	Kind     error
+	Provider string
+	URL      string
+	Status   int
+	Err      error
+}
+
+func (e *ProviderError) Error() string {
+	msg := fmt.Sprintf("%s (%s): %s", e.Provider, e.URL, e.Kind)
+	if e.Status != 0 {
+		msg = fmt.Sprintf("%s: HTTP status = %d", msg, e.Status)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err)
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrRateLimited) etc work.
+func (e *ProviderError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// statusError provides the kind of failure based on the HTTP response status.
+func (c client) statusError(u *url.URL, status int) error {
+	var kind error
+	switch {
+	case status == http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		kind = ErrUnauthorized
+	case status == http.StatusNotFound:
+		kind = ErrNotFound
+	case status >= http.StatusInternalServerError:
+		kind = ErrProviderUnavailable
+	default:
+		kind = ErrUnexpectedStatus
+	}
+
+	return &ProviderError{
+		Kind:     kind,
+		Provider: c.provider,
+		URL:      c.redact(u),
+		Status:   status,
+	}
+}
+
+// requestError is a failure to get any response.
+func (c client) requestError(u *url.URL, err error) error {
+	ue := &url.Error{}
+	if errors.As(err, &ue) {
+		err = &url.Error{Op: ue.Op, URL: c.redact(u), Err: ue.Err}
+	}
+
+	return &ProviderError{
+		Kind:     ErrProviderUnavailable,
+		Provider: c.provider,
+		URL:      c.redact(u),
+		Err:      err,
+	}
+}
+
+// bodyError is a failure to read the (compressed) response body.
+func (c client) bodyError(u *url.URL, err error) error {
+	kind := ErrDecode
+	if errors.Is(err, ErrResponseTooLarge) {
+		kind = ErrResponseTooLarge
+	}
+
+	return &ProviderError{
+		Kind:     kind,
+		Provider: c.provider,
+		URL:      c.redact(u),
+		Err:      err,
+	}
+}
+
+// decodeError is a failure to process the provider response.
+func (c client) decodeError(err error) error {
+	return &ProviderError{
+		Kind:     ErrDecode,
+		Provider: c.provider,
+		URL:      c.redact(c.URL),
+		Err:      err,
+	}
+}
+
+// notFoundError is a successful response without the requested information.
+func (c client) notFoundError(msg string) error {
+	return &ProviderError{
+		Kind:     ErrNotFound,
+		Provider: c.provider,
+		URL:      c.redact(c.URL),
+		Err:      errors.New(msg),
+	}
+}
+
+// redact removes the API key from the URL.
+func (c client) redact(u *url.URL) string {
+	ru := *u
+	q := ru.Query()
+	if q.Get(c.urlKey) != "" {
+		q.Set(c.urlKey, "REDACTED")
+		ru.RawQuery = q.Encode()
+	}
+	return ru.String()
+}
@@ -0,0 +1,125 @@
+// +build unit_tests
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+const apiKey = "secret-api-key"
+
+var pd = photo.Data{
+	ArticleID: "article1",
+	ID:        1,
+	Date:      "2019-10-27T13:27:58Z",
+	LatLon: photo.LatLon{
+		Latitude:  "40.647863",
+		Longitude: "14.366958",
+	},
+}
+
+func TestLocate_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		want       error
+		wantStatus int
+	}{
+		{
+			name:       "too many requests",
+			status:     http.StatusTooManyRequests,
+			want:       client.ErrRateLimited,
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "unauthorized",
+			status:     http.StatusUnauthorized,
+			want:       client.ErrUnauthorized,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "forbidden",
+			status:     http.StatusForbidden,
+			want:       client.ErrUnauthorized,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "not found",
+			status:     http.StatusNotFound,
+			want:       client.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "service unavailable",
+			status:     http.StatusServiceUnavailable,
+			want:       client.ErrProviderUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "bad request",
+			status:     http.StatusBadRequest,
+			want:       client.ErrUnexpectedStatus,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid json",
+			status: http.StatusOK,
+			body:   `{"items": [`,
+			want:   client.ErrDecode,
+		},
+		{
+			name:   "no address",
+			status: http.StatusOK,
+			body:   `{"items": []}`,
+			want:   client.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			ac, err := client.NewAddressesClient(conf.Setup{HereURL: ts.URL, HereAPIKey: apiKey})
+			require.NoError(t, err)
+
+			_, err = ac.Locate(context.Background(), pd)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, tt.want), "got %s", err)
+
+			pe := &client.ProviderError{}
+			require.True(t, errors.As(err, &pe))
+			require.Equal(t, "here", pe.Provider)
+			require.Equal(t, tt.wantStatus, pe.Status)
+			require.NotContains(t, pe.URL, apiKey)
+			require.NotContains(t, err.Error(), apiKey)
+		})
+	}
+}
+
+func TestLocate_ProviderUnavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	ac, err := client.NewAddressesClient(conf.Setup{HereURL: url, HereAPIKey: apiKey})
+	require.NoError(t, err)
+
+	_, err = ac.Locate(context.Background(), pd)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, client.ErrProviderUnavailable), "got %s", err)
+	require.False(t, strings.Contains(err.Error(), apiKey), "API key not redacted: %s", err)
+}
@@ -5,80 +5,86 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
-	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/tamarakaufler/travel-article-headings/internal/client/response/here"
 	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
 )
 
+// Addresses provides photo location.
 type Addresses interface {
-	EnhanceWithLocation(ctx context.Context, chans photo.Channel, pd photo.Data)
+	Locate(ctx context.Context, pd photo.Data) (photo.Location, error)
 }
 
 type addressesClient struct {
 	client client
 }
 
-func NewAddressesClient(cfg conf.Setup) (addressesClient, error) {
+func NewAddressesClient(cfg conf.Setup, opts ...Option) (addressesClient, error) {
 	hereURL, err := url.Parse(cfg.HereURL)
 	if err != nil {
 		return addressesClient{}, err
 	}
+	catalog, err := locale.Load(cfg.Locale)
+	if err != nil {
+		return addressesClient{}, err
+	}
 
-	return addressesClient{
-		client: client{
-			URL:    hereURL,
-			APIKey: cfg.HereAPIKey,
-			urlKey: "apiKey",
+	c := client{
+		URL:      hereURL,
+		APIKey:   cfg.HereAPIKey,
+		urlKey:   "apiKey",
+		provider: "here",
 
-			mu: &sync.Mutex{},
-		},
+		httpClient:      NewHTTPClient(cfg),
+		maxResponseSize: cfg.HTTPMaxResponseSize,
+		// the place names are in the language of the locale.
+		lang: catalog.Lang,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return addressesClient{
+		client: c,
 	}, nil
 }
 
 var _ Addresses = addressesClient{}
 
-// EnhanceWithLocation ...
-func (ac addressesClient) EnhanceWithLocation(ctx context.Context, chans photo.Channel, pd photo.Data,
-) {
+// Locate retrieves the photo location using reverse geocoding.
+func (ac addressesClient) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
 	ll := pd.LatLon
 	at := latlonToAt(ll)
 	q := map[string]string{
 		"at":   at,
-		"lang": "en-US",
+		"lang": ac.client.lang,
 	}
 
-	//t1 := time.Now()
 	b, err := ac.client.MakeGetRequest(ctx, q)
 	if err != nil {
-		chans.Error <- errors.Wrapf(err, "failure to retrieve location data for LatLon %+v", pd).Error()
-		return
+		return photo.Location{}, errors.Wrapf(err, "failure to retrieve location data for LatLon %+v", ll)
 	}
-	//t2 := time.Now()
-	//log.Printf("\t\t--> call took %s", t2.Sub(t1).String())
 
 	res := &here.ReverseGeocode{}
 	err = json.Unmarshal(b, res)
-
 	if err != nil {
-		chans.Error <- errors.Wrapf(err, "failure to retrieve location data for LatLon %+v", pd).Error()
-		return
+		return photo.Location{}, errors.Wrapf(ac.client.decodeError(err),
+			"failure to retrieve location data for LatLon %+v", ll)
 	}
 	if len(res.Items) == 0 {
-		chans.Error <- fmt.Errorf("failure to retrieve location data for LatLon %+v", ll).Error()
-		return
+		return photo.Location{}, ac.client.notFoundError(
+			fmt.Sprintf("failure to retrieve location data for LatLon %+v", ll))
 	}
 
-	chans.Location <- photo.LocationM{
-		ArticleID: pd.ArticleID,
-		PhotoID:   pd.ID,
-		Location: photo.Location{
-			Country: res.Items[0].Address.CountryName,
-			City:    res.Items[0].Address.City,
-		},
-	}
+	return photo.Location{
+		Country:     res.Items[0].Address.CountryName,
+		CountryCode: res.Items[0].Address.CountryCode,
+		State:       res.Items[0].Address.State,
+		City:        res.Items[0].Address.City,
+	}, nil
 }
 
 func latlonToAt(ll photo.LatLon) string {
@@ -0,0 +1,49 @@
+// +build unit_tests
+
+package client_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+func TestLocate_Language(t *testing.T) {
+	body, err := ioutil.ReadFile(fixture)
+	require.NoError(t, err)
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{locale: "", want: "en-US"},
+		{locale: "en", want: "en-US"},
+		{locale: "de", want: "de-DE"},
+		{locale: "it", want: "it-IT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.want, r.URL.Query().Get("lang"))
+				require.Equal(t, tt.want, r.Header.Get("Accept-Language"))
+				_, _ = w.Write(body)
+			}))
+			defer ts.Close()
+
+			ac, err := client.NewAddressesClient(conf.Setup{HereURL: ts.URL, HereAPIKey: apiKey, Locale: tt.locale})
+			require.NoError(t, err)
+
+			_, err = ac.Locate(context.Background(), photo.Data{
+				LatLon: photo.LatLon{Latitude: "40.628075", Longitude: "14.375383"},
+			})
+			require.NoError(t, err)
+		})
+	}
+}
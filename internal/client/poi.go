@@ -2,45 +2,158 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/url"
-	"sync"
+	"strconv"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/tamarakaufler/travel-article-headings/internal/client/response/places"
 	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
 )
 
+// Poi provides the number of places of interest of each kind near the photo location.
 type Poi interface {
-	EnhanceWithPlacesOfInterest(ctx context.Context, chans photo.Channel, pd photo.Data)
+	PlacesOfInterest(ctx context.Context, pd photo.Data) (map[string]int, error)
+}
+
+// LandmarkPoi is a Poi that also provides the named landmarks near the photo location, retrieved
+// together with the places of interest.
+type LandmarkPoi interface {
+	Poi
+	PlacesAndLandmarks(ctx context.Context, pd photo.Data) (map[string]int, []photo.Landmark, error)
 }
 
 type poiClient struct {
 	client client
+	mock   bool
 }
 
-func NewPoiClient(cfg conf.Setup) (poiClient, error) {
+func NewPoiClient(cfg conf.Setup, opts ...Option) (poiClient, error) {
 	hereURL, err := url.Parse(cfg.GooglePlacesURL)
 	if err != nil {
 		return poiClient{}, err
 	}
 
-	return poiClient{
-		client: client{
-			URL:    hereURL,
-			APIKey: cfg.HereAPIKey,
-			urlKey: "key",
+	c := client{
+		URL:      hereURL,
+		APIKey:   cfg.GooglePlacesAPIKey,
+		urlKey:   "key",
+		provider: "places",
+
+		httpClient:      NewHTTPClient(cfg),
+		maxResponseSize: cfg.HTTPMaxResponseSize,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
 
-			mu: &sync.Mutex{},
-		},
+	return poiClient{
+		client: c,
+		mock:   cfg.PoiProvider != HTTPProvider,
 	}, nil
 }
 
-var _ Poi = poiClient{}
+var _ LandmarkPoi = poiClient{}
+
+// PlaceTypes maps the places search types to the places of interest.
+var PlaceTypes = map[string]string{
+	"restaurant":       "Restaurants",
+	"casino":           "Casinos",
+	"museum":           "Museums",
+	"bar":              "Bars",
+	"swimming_pool":    "Swimming Pools",
+	"cafe":             "Cafes",
+	"pub":              "Pubs",
+	"park":             "Parks",
+	"theater":          "Theatres",
+	"movie_theater":    "Cinemas",
+	"playground":       "Playgrounds",
+	"shopping_mall":    "Shopping Centres",
+	"zoo":              "Zoos",
+	"botanical_garden": "Botanical Gardens",
+}
+
+// landmarkType is the places search type of the landmarks not among the places of interest, eg monuments.
+const landmarkType = "tourist_attraction"
 
-// EnhanceWithPlacesOfInterest ...
-func (pc poiClient) EnhanceWithPlacesOfInterest(ctx context.Context, chans photo.Channel, pd photo.Data,
-) {
+// placesRadius is the search radius around the photo location in meters.
+const placesRadius = "500"
+
+// PlacesOfInterest retrieves the number of places of interest near the photo location.
+// The mock provider (default) fakes the request.
+func (pc poiClient) PlacesOfInterest(ctx context.Context, pd photo.Data) (map[string]int, error) {
+	pois, _, err := pc.PlacesAndLandmarks(ctx, pd)
+	return pois, err
+}
+
+// PlacesAndLandmarks retrieves the number of places of interest near the photo location together with
+// the named landmarks: the places of interest and tourist attractions, with their distance from the photo
+// and their number of user ratings as the popularity. The mock provider (default) fakes the request.
+func (pc poiClient) PlacesAndLandmarks(ctx context.Context, pd photo.Data) (map[string]int, []photo.Landmark, error) {
+	if pc.mock {
+		return mockPlacesOfInterest(), mockLandmarks(), nil
+	}
+
+	q := map[string]string{
+		"location": latlonToAt(pd.LatLon),
+		"radius":   placesRadius,
+	}
+
+	b, err := pc.client.MakeGetRequest(ctx, q)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failure to retrieve places of interest for LatLon %+v", pd.LatLon)
+	}
+
+	res := &places.NearbySearch{}
+	err = json.Unmarshal(b, res)
+	if err != nil {
+		return nil, nil, errors.Wrapf(pc.client.decodeError(err),
+			"failure to retrieve places of interest for LatLon %+v", pd.LatLon)
+	}
+
+	lat, errLat := strconv.ParseFloat(pd.LatLon.Latitude, 64)
+	lon, errLon := strconv.ParseFloat(pd.LatLon.Longitude, 64)
+
+	pois := map[string]int{}
+	landmarks := []photo.Landmark{}
+	for _, r := range res.Results {
+		category, landmark := "", false
+		for _, t := range r.Types {
+			if p, ok := PlaceTypes[t]; ok {
+				pois[p]++
+				if category == "" {
+					category = p
+				}
+				landmark = true
+			}
+			if t == landmarkType {
+				landmark = true
+			}
+		}
+		if !landmark || r.Name == "" {
+			continue
+		}
+
+		l := photo.Landmark{Name: r.Name, Category: category, Popularity: r.UserRatingsTotal}
+		if errLat == nil && errLon == nil {
+			l.Distance = 1000 * trip.Haversine(lat, lon, r.Geometry.Location.Lat, r.Geometry.Location.Lng)
+		}
+		landmarks = append(landmarks, l)
+	}
+	if len(pois) == 0 {
+		return nil, nil, pc.client.notFoundError(
+			fmt.Sprintf("failure to retrieve places of interest for LatLon %+v", pd.LatLon))
+	}
+
+	return pois, landmarks, nil
+}
+
+func mockPlacesOfInterest() map[string]int {
 	placesL := []string{"Restaurants", "Casinos", "Museums", "Bars", "Swimming Pools", "Cafes", "Pubs",
 		"Parks", "Theatres", "Cinemas", "Playgrounds", "Shopping Centres", "Zoos", "Botanical Gardens"}
 
@@ -53,9 +166,28 @@ func (pc poiClient) EnhanceWithPlacesOfInterest(ctx context.Context, chans photo
 		places[p] = c
 	}
 
-	chans.Poi <- photo.PoiM{
-		ArticleID: pd.ArticleID,
-		PhotoID:   pd.ID,
-		POI:       places,
+	return places
+}
+
+func mockLandmarks() []photo.Landmark {
+	landmarkL := []photo.Landmark{
+		{Name: "Old Town Square", Popularity: 52000},
+		{Name: "Natural History Museum", Category: "Museums", Popularity: 31000},
+		{Name: "Cathedral of St Mary", Popularity: 4100},
+		{Name: "Harbour Lighthouse", Popularity: 2300},
+		{Name: "Royal Theatre", Category: "Theatres", Popularity: 8700},
 	}
+
+	rand.Seed(time.Now().UnixNano())
+
+	landmarks := []photo.Landmark{}
+	for _, l := range landmarkL {
+		if rand.Intn(2) == 0 {
+			continue
+		}
+		l.Distance = float64(rand.Intn(500))
+		landmarks = append(landmarks, l)
+	}
+
+	return landmarks
 }
@@ -0,0 +1,55 @@
+// +build unit_tests
+
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+)
+
+func TestPlacesAndLandmarks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "results": [
+			{"name": "Bar Ercolano", "types": ["bar", "restaurant", "establishment"],
+				"geometry": {"location": {"lat": 40.647863, "lng": 14.366958}}, "user_ratings_total": 120},
+			{"name": "Museo Correale", "types": ["museum", "tourist_attraction"],
+				"geometry": {"location": {"lat": 40.648763, "lng": 14.366958}}, "user_ratings_total": 2300},
+			{"name": "Marina Grande", "types": ["tourist_attraction", "point_of_interest"],
+				"geometry": {"location": {"lat": 40.646963, "lng": 14.366958}}, "user_ratings_total": 8100},
+			{"name": "Esso", "types": ["gas_station"],
+				"geometry": {"location": {"lat": 40.647863, "lng": 14.366958}}},
+			{"types": ["tourist_attraction"]}
+		]}`))
+	}))
+	defer ts.Close()
+
+	pc, err := client.NewPoiClient(conf.Setup{GooglePlacesURL: ts.URL, PoiProvider: client.HTTPProvider})
+	require.NoError(t, err)
+
+	pois, landmarks, err := pc.PlacesAndLandmarks(context.Background(), pd)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"Bars": 1, "Restaurants": 1, "Museums": 1}, pois)
+
+	require.Len(t, landmarks, 3)
+	require.Equal(t, "Bar Ercolano", landmarks[0].Name)
+	require.Equal(t, "Bars", landmarks[0].Category)
+	require.InDelta(t, 0, landmarks[0].Distance, 0.1)
+	require.Equal(t, 120, landmarks[0].Popularity)
+	require.Equal(t, "Museums", landmarks[1].Category)
+	// 0.0009° of latitude is 100m.
+	require.InDelta(t, 100, landmarks[1].Distance, 0.5)
+	require.Equal(t, "Marina Grande", landmarks[2].Name)
+	require.Empty(t, landmarks[2].Category)
+	require.Equal(t, 8100, landmarks[2].Popularity)
+
+	// the places of interest are the same without the landmarks.
+	pois2, err := pc.PlacesOfInterest(context.Background(), pd)
+	require.NoError(t, err)
+	require.Equal(t, pois, pois2)
+}
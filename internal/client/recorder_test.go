@@ -0,0 +1,204 @@
+// +build unit_tests
+
+package client_test
+
+import (
+	"compress/gzip"
+	"context"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+// Refresh the cassettes (requires 3rd party credentials):
+//	HERE_API_KEY=xxxx WEATHER_URL=... WEATHER_API_KEY=zzzz GOOGLE_PLACES_URL=... GOOGLE_PLACES_API_KEY=yyyy \
+//	go test -tags unit_tests ./internal/client -run Cassette -record
+var record = flag.Bool("record", false, "record 3rd party responses into the cassettes")
+
+// placesNearbySearchURL is the places endpoint the places of interest cassette is replayed from.
+const placesNearbySearchURL = "https://maps.googleapis.com/maps/api/place/nearbysearch/json"
+
+func TestLocate_Cassette(t *testing.T) {
+	tests := []struct {
+		name   string
+		latLon photo.LatLon
+		want   photo.Location
+	}{
+		{
+			name:   "Sorrento",
+			latLon: photo.LatLon{Latitude: "40.628075", Longitude: "14.375383"},
+			want:   photo.Location{Country: "Italy", CountryCode: "ITA", State: "Campania", City: "Sorrento"},
+		},
+	}
+
+	cfg := cassetteConf(t)
+	rec := cassetteRecorder(t, cfg, "response/cassettes/here_revgeocode.json", "apiKey")
+
+	ac, err := client.NewAddressesClient(cfg, client.WithTransport(rec))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := ac.Locate(context.Background(), photo.Data{LatLon: tt.latLon})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, loc)
+		})
+	}
+}
+
+func TestWeather_Cassette(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   photo.Data
+		want string
+	}{
+		{
+			name: "Sorrento",
+			pd: photo.Data{
+				Date:   "2019-10-16T12:00:00Z",
+				LatLon: photo.LatLon{Latitude: "40.628075", Longitude: "14.375383"},
+			},
+			want: "sunny",
+		},
+	}
+
+	cfg := cassetteConf(t)
+	cfg.WeatherProvider = client.HTTPProvider
+	rec := cassetteRecorder(t, cfg, "response/cassettes/weather_historical.json", "key")
+
+	wc, err := client.NewWeatherClient(cfg, client.WithTransport(rec))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := wc.Weather(context.Background(), tt.pd)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, w)
+		})
+	}
+}
+
+func TestPlacesAndLandmarks_Cassette(t *testing.T) {
+	tests := []struct {
+		name          string
+		latLon        photo.LatLon
+		wantPois      map[string]int
+		wantLandmarks []string
+	}{
+		{
+			name:          "Sorrento",
+			latLon:        photo.LatLon{Latitude: "40.628075", Longitude: "14.375383"},
+			wantPois:      map[string]int{"Museums": 1, "Restaurants": 1, "Bars": 1, "Cafes": 1},
+			wantLandmarks: []string{"Museo Correale di Terranova", "Piazza Tasso", "Ristorante Bagni Delfino", "Bar Ercolano"},
+		},
+	}
+
+	cfg := cassetteConf(t)
+	cfg.PoiProvider = client.HTTPProvider
+	rec := cassetteRecorder(t, cfg, "response/cassettes/places_nearbysearch.json", "key")
+
+	pc, err := client.NewPoiClient(cfg, client.WithTransport(rec))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pois, landmarks, err := pc.PlacesAndLandmarks(context.Background(), photo.Data{LatLon: tt.latLon})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPois, pois)
+
+			names := []string{}
+			for _, l := range landmarks {
+				names = append(names, l.Name)
+				require.Positive(t, l.Popularity)
+				require.Less(t, l.Distance, 500.0)
+			}
+			require.Equal(t, tt.wantLandmarks, names)
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		_, _ = gw.Write([]byte(locationResponse))
+		_ = gw.Close()
+	}))
+	cfg := conf.Setup{HereURL: ts.URL, HereAPIKey: apiKey}
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := client.NewRecorder(path, client.Record, "apiKey")
+	require.NoError(t, err)
+	ac, err := client.NewAddressesClient(cfg, client.WithTransport(rec))
+	require.NoError(t, err)
+
+	loc, err := ac.Locate(context.Background(), pd)
+	require.NoError(t, err)
+	require.Equal(t, "Sorrento", loc.City)
+	require.NoError(t, rec.Save())
+	ts.Close()
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), apiKey)
+	require.Contains(t, string(data), "apiKey=REDACTED")
+
+	// replayed without the 3rd party
+	rec, err = client.NewRecorder(path, client.Replay, "apiKey")
+	require.NoError(t, err)
+	ac, err = client.NewAddressesClient(cfg, client.WithTransport(rec))
+	require.NoError(t, err)
+
+	loc, err = ac.Locate(context.Background(), pd)
+	require.NoError(t, err)
+	require.Equal(t, "Sorrento", loc.City)
+
+	pd2 := pd
+	pd2.LatLon.Latitude = "41.0"
+	_, err = ac.Locate(context.Background(), pd2)
+	require.Error(t, err)
+	require.ErrorIs(t, err, client.ErrNoInteraction)
+	require.ErrorIs(t, err, client.ErrProviderUnavailable)
+}
+
+// cassetteConf uses the real configuration when recording.
+func cassetteConf(t *testing.T) conf.Setup {
+	if !*record && os.Getenv("HERE_API_KEY") == "" {
+		require.NoError(t, os.Setenv("HERE_API_KEY", "xxxxx"))
+		defer os.Unsetenv("HERE_API_KEY")
+	}
+	if !*record && os.Getenv("GOOGLE_PLACES_URL") == "" {
+		require.NoError(t, os.Setenv("GOOGLE_PLACES_URL", placesNearbySearchURL))
+		defer os.Unsetenv("GOOGLE_PLACES_URL")
+	}
+
+	cfg, err := conf.Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func cassetteRecorder(t *testing.T, cfg conf.Setup, path string, scrub ...string) *client.Recorder {
+	if !*record {
+		rec, err := client.NewRecorder(path, client.Replay, scrub...)
+		require.NoError(t, err)
+		return rec
+	}
+
+	rec, err := client.NewRecorder(path, client.Record, scrub...)
+	require.NoError(t, err)
+	rec.Next = client.NewHTTPClient(cfg).Transport
+	t.Cleanup(func() {
+		require.NoError(t, rec.Save())
+	})
+	return rec
+}
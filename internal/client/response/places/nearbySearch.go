@@ -0,0 +1,20 @@
+package places
+
+// NearbySearch is the places nearby search response.
+type NearbySearch struct {
+	Results []struct {
+		Name     string `json:"name"`
+		PlaceID  string `json:"place_id"`
+		Geometry struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+		Rating           float64  `json:"rating"`
+		UserRatingsTotal int      `json:"user_ratings_total"`
+		Types            []string `json:"types"`
+		Vicinity         string   `json:"vicinity"`
+	} `json:"results"`
+	Status string `json:"status"`
+}
@@ -0,0 +1,13 @@
+package weather
+
+// Historical is the historical weather response for a place and time.
+type Historical struct {
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+	Time int64   `json:"dt"`
+
+	Weather []struct {
+		Main        string `json:"main"`
+		Description string `json:"description"`
+	} `json:"weather"`
+}
@@ -2,76 +2,149 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/url"
-	"sync"
+	"strconv"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/tamarakaufler/travel-article-headings/internal/client/response/weather"
 	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/holiday"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/season"
+	"github.com/tamarakaufler/travel-article-headings/internal/tz"
 )
 
+// Weather provides weather at the time and place of the photo.
 type Weather interface {
-	EnhanceWithWeather(ctx context.Context, chans photo.Channel, pd photo.Data)
+	Weather(ctx context.Context, pd photo.Data) (string, error)
 }
 
 type weatherClient struct {
 	client client
+	mock   bool
 }
 
-func NewWeatherClient(cfg conf.Setup) (weatherClient, error) {
+func NewWeatherClient(cfg conf.Setup, opts ...Option) (weatherClient, error) {
 	hereURL, err := url.Parse(cfg.WeatherURL)
 	if err != nil {
 		return weatherClient{}, err
 	}
 
-	return weatherClient{
-		client: client{
-			URL:    hereURL,
-			APIKey: cfg.HereAPIKey,
-			urlKey: "key",
+	c := client{
+		URL:      hereURL,
+		APIKey:   cfg.WeatherAPIKey,
+		urlKey:   "key",
+		provider: "weather",
 
-			mu: &sync.Mutex{},
-		},
+		httpClient:      NewHTTPClient(cfg),
+		maxResponseSize: cfg.HTTPMaxResponseSize,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return weatherClient{
+		client: c,
+		mock:   cfg.WeatherProvider != HTTPProvider,
 	}, nil
 }
 
 var _ Weather = weatherClient{}
 
-// EnhanceWithWeather fakes request for historical weather information.
-func (wc weatherClient) EnhanceWithWeather(ctx context.Context, chans photo.Channel, pd photo.Data,
-) {
+// Weather retrieves historical weather information. The mock provider
+// (default) fakes the request.
+func (wc weatherClient) Weather(ctx context.Context, pd photo.Data) (string, error) {
+	if wc.mock {
+		return mockWeather(), nil
+	}
+
+	t, err := photo.ParseDate(pd.Date)
+	if err != nil {
+		return "", err
+	}
+	q := map[string]string{
+		"lat": pd.LatLon.Latitude,
+		"lon": pd.LatLon.Longitude,
+		"dt":  strconv.FormatInt(t.Unix(), 10),
+	}
+
+	b, err := wc.client.MakeGetRequest(ctx, q)
+	if err != nil {
+		return "", errors.Wrapf(err, "failure to retrieve weather data for LatLon %+v", pd.LatLon)
+	}
+
+	res := &weather.Historical{}
+	err = json.Unmarshal(b, res)
+	if err != nil {
+		return "", errors.Wrapf(wc.client.decodeError(err),
+			"failure to retrieve weather data for LatLon %+v", pd.LatLon)
+	}
+	if len(res.Weather) == 0 || res.Weather[0].Description == "" {
+		return "", wc.client.notFoundError(
+			fmt.Sprintf("failure to retrieve weather data for LatLon %+v", pd.LatLon))
+	}
+
+	return res.Weather[0].Description, nil
+}
+
+func mockWeather() string {
 	weatherL := []string{"rainy", "wet", "boiling hot", "sunny", "stormy", "drizzly", "hazy", "scorching",
 		"hot", "unbearably hot", "miserably cold"}
 
 	rand.Seed(time.Now().UnixNano())
 	i := rand.Intn(len(weatherL))
 
-	w := photo.WeatherM{
-		ArticleID: pd.ArticleID,
-		PhotoID:   pd.ID,
-		Weather:   weatherL[i],
-	}
+	return weatherL[i]
+}
 
-	ti, err := DateToSeason(pd.Date)
-	if err == nil {
-		w.TimeInfo = ti
+// DateToSeason ...
+func DateToSeason(d string) (photo.TimeInfo, error) {
+	t, err := photo.ParseDate(d)
+	if err != nil {
+		return photo.TimeInfo{}, err
 	}
 
-	chans.Weather <- w
+	return TimeToSeason(t), nil
 }
 
-// DateToSeason ...
-func DateToSeason(d string) (photo.TimeInfo, error) {
-	t, err := time.Parse(time.RFC3339, d)
+// LocalTimeInfo provides the time information of the photo in the local time of the photo
+// position. Dates without a timezone are taken as UTC. Photos without a valid position
+// keep the date timezone and get the northern hemisphere seasons.
+func LocalTimeInfo(pd photo.Data) (photo.TimeInfo, error) {
+	return LocalTimeInfoWith(pd, photo.Location{}, season.Default, nil)
+}
+
+// LocalTimeInfoWith is LocalTimeInfo with the seasons resolved by the season resolver
+// for the photo country and with the holidays of the photo country in the holiday calendar.
+func LocalTimeInfoWith(pd photo.Data, loc photo.Location, seasons season.Resolver, holidays *holiday.Calendar,
+) (photo.TimeInfo, error) {
+	t, err := photo.ParseDate(pd.Date)
 	if err != nil {
-		layout := "2006-01-02 15:04:05"
-		t, err = time.Parse(layout, d)
-		if err != nil {
-			return photo.TimeInfo{}, err
-		}
+		return photo.TimeInfo{}, err
 	}
 
+	lat, errLat := strconv.ParseFloat(pd.LatLon.Latitude, 64)
+	lon, errLon := strconv.ParseFloat(pd.LatLon.Longitude, 64)
+	if errLat != nil || errLon != nil || (lat == 0 && lon == 0) {
+		ti := TimeToSeason(t)
+		ti.Holidays = holidays.Holidays(t, loc.CountryCode)
+		return ti, nil
+	}
+
+	t = tz.Default().LocalIn(t, lat, lon, loc.CountryCode)
+	ti := TimeToSeason(t)
+	ti.Season = seasons.Season(t, lat, loc.CountryCode)
+	ti.Holidays = holidays.Holidays(t, loc.CountryCode)
+
+	return ti, nil
+}
+
+// TimeToSeason derives the time information with the northern hemisphere meteorological seasons.
+func TimeToSeason(t time.Time) photo.TimeInfo {
 	m := t.Month()
 	wd := t.Weekday()
 
@@ -88,9 +161,22 @@ func DateToSeason(d string) (photo.TimeInfo, error) {
 		s = "Winter"
 	}
 
+	var td string
+	switch h := t.Hour(); {
+	case h >= 5 && h < 12:
+		td = "Morning"
+	case h >= 12 && h < 17:
+		td = "Afternoon"
+	case h >= 17 && h < 21:
+		td = "Evening"
+	default:
+		td = "Night"
+	}
+
 	return photo.TimeInfo{
-		Weekday: wd.String(),
-		Month:   m.String(),
-		Season:  s,
-	}, nil
+		Weekday:   wd.String(),
+		Month:     m.String(),
+		Season:    s,
+		TimeOfDay: td,
+	}
 }
@@ -5,9 +5,13 @@ package client_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
 	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	"github.com/tamarakaufler/travel-article-headings/internal/holiday"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/season"
 )
 
 func Test_dateToSeason(t *testing.T) {
@@ -26,9 +30,10 @@ func Test_dateToSeason(t *testing.T) {
 				d: "2019-10-29T11:11:59Z",
 			},
 			want: photo.TimeInfo{
-				Weekday: "Tuesday",
-				Month:   "October",
-				Season:  "Autumn",
+				Weekday:   "Tuesday",
+				Month:     "October",
+				Season:    "Autumn",
+				TimeOfDay: "Morning",
 			},
 			wantErr: false,
 		},
@@ -38,9 +43,10 @@ func Test_dateToSeason(t *testing.T) {
 				d: "2019-03-29T11:11:59Z",
 			},
 			want: photo.TimeInfo{
-				Weekday: "Friday",
-				Month:   "March",
-				Season:  "Spring",
+				Weekday:   "Friday",
+				Month:     "March",
+				Season:    "Spring",
+				TimeOfDay: "Morning",
 			},
 			wantErr: false,
 		},
@@ -50,9 +56,10 @@ func Test_dateToSeason(t *testing.T) {
 				d: "2019-07-29T11:11:59Z",
 			},
 			want: photo.TimeInfo{
-				Weekday: "Monday",
-				Month:   "July",
-				Season:  "Summer",
+				Weekday:   "Monday",
+				Month:     "July",
+				Season:    "Summer",
+				TimeOfDay: "Morning",
 			},
 			wantErr: false,
 		},
@@ -62,9 +69,10 @@ func Test_dateToSeason(t *testing.T) {
 				d: "2019-12-29 11:11:59",
 			},
 			want: photo.TimeInfo{
-				Weekday: "Sunday",
-				Month:   "December",
-				Season:  "Winter",
+				Weekday:   "Sunday",
+				Month:     "December",
+				Season:    "Winter",
+				TimeOfDay: "Morning",
 			},
 			wantErr: false,
 		},
@@ -82,3 +90,155 @@ func Test_dateToSeason(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalTimeInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   photo.Data
+		want photo.TimeInfo
+	}{
+		{
+			name: "New York the evening before",
+			pd: photo.Data{Date: "2019-11-02T00:30:00Z",
+				LatLon: photo.LatLon{Latitude: "40.713", Longitude: "-74.006"}},
+			want: photo.TimeInfo{Weekday: "Friday", Month: "November", Season: "Autumn", TimeOfDay: "Evening"},
+		},
+		{
+			name: "Sorrento after midnight",
+			pd: photo.Data{Date: "2019-08-31 22:30:00",
+				LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+			want: photo.TimeInfo{Weekday: "Sunday", Month: "September", Season: "Autumn", TimeOfDay: "Night"},
+		},
+		{
+			name: "Sydney the next morning",
+			pd: photo.Data{Date: "2020-01-10T20:00:00Z",
+				LatLon: photo.LatLon{Latitude: "-33.869", Longitude: "151.209"}},
+			want: photo.TimeInfo{Weekday: "Saturday", Month: "January", Season: "Summer", TimeOfDay: "Morning"},
+		},
+		{
+			name: "Bangkok in the wet season",
+			pd: photo.Data{Date: "2020-07-10T05:00:00Z",
+				LatLon: photo.LatLon{Latitude: "13.756", Longitude: "100.502"}},
+			want: photo.TimeInfo{Weekday: "Friday", Month: "July", Season: "Wet season", TimeOfDay: "Afternoon"},
+		},
+		{
+			name: "explicit offset",
+			pd: photo.Data{Date: "2019-11-02T21:00:00-04:00",
+				LatLon: photo.LatLon{Latitude: "40.713", Longitude: "-74.006"}},
+			want: photo.TimeInfo{Weekday: "Saturday", Month: "November", Season: "Autumn", TimeOfDay: "Night"},
+		},
+		{
+			name: "no position",
+			pd:   photo.Data{Date: "2019-11-02T01:00:00Z", LatLon: photo.LatLon{Latitude: "0", Longitude: "0"}},
+			want: photo.TimeInfo{Weekday: "Saturday", Month: "November", Season: "Autumn", TimeOfDay: "Night"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.LocalTimeInfo(tt.pd)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLocalTimeInfoWith(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   photo.Data
+		loc  photo.Location
+		want []string
+	}{
+		{
+			name: "Vienna Christmas markets",
+			pd: photo.Data{Date: "2019-12-10T17:00:00Z",
+				LatLon: photo.LatLon{Latitude: "48.208", Longitude: "16.373"}},
+			loc:  photo.Location{Country: "Austria", CountryCode: "AUT", City: "Vienna"},
+			want: []string{"Christmas markets"},
+		},
+		{
+			name: "Sorrento Easter Monday in the local time",
+			pd: photo.Data{Date: "2019-04-21T22:30:00Z",
+				LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+			loc:  photo.Location{Country: "Italy", CountryCode: "ITA", City: "Sorrento"},
+			want: []string{"Easter weekend"},
+		},
+		{
+			name: "Sorrento after Easter in the local time",
+			pd: photo.Data{Date: "2019-04-22T22:30:00Z",
+				LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+			loc: photo.Location{Country: "Italy", CountryCode: "ITA", City: "Sorrento"},
+		},
+		{
+			name: "no country code",
+			pd: photo.Data{Date: "2019-12-25T12:00:00Z",
+				LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+			loc: photo.Location{Country: "Italy", City: "Sorrento"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.LocalTimeInfoWith(tt.pd, tt.loc, season.Default, holiday.Default())
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Holidays)
+		})
+	}
+}
+
+func TestLocalTimeInfoWith_TimeOfDay(t *testing.T) {
+	tests := []struct {
+		name string
+		pd   photo.Data
+		loc  photo.Location
+		want string
+	}{
+		{
+			name: "Paris summer evening",
+			pd: photo.Data{Date: "2019-07-13T15:30:00Z",
+				LatLon: photo.LatLon{Latitude: "48.857", Longitude: "2.352"}},
+			loc:  photo.Location{Country: "France", CountryCode: "FRA", City: "Paris"},
+			want: "Evening",
+		},
+		{
+			name: "Vienna summer night",
+			pd: photo.Data{Date: "2019-07-13T19:30:00Z",
+				LatLon: photo.LatLon{Latitude: "48.208", Longitude: "16.373"}},
+			loc:  photo.Location{Country: "Austria", CountryCode: "AUT", City: "Vienna"},
+			want: "Night",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.LocalTimeInfoWith(tt.pd, tt.loc, season.Default, holiday.Default())
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.TimeOfDay)
+		})
+	}
+}
+
+func TestLocalTimeInfoWith_CustomSeason(t *testing.T) {
+	seasons := season.Resolver{
+		Calendar: season.Custom,
+		Table:    season.Table{"ITA": {time.October: "Harvest season"}},
+	}
+	pd := photo.Data{Date: "2019-10-27T13:27:58Z", LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}}
+
+	tests := []struct {
+		name string
+		loc  photo.Location
+		want string
+	}{
+		{name: "English place names", loc: photo.Location{Country: "Italy", CountryCode: "ITA"},
+			want: "Harvest season"},
+		{name: "localized place names", loc: photo.Location{Country: "Italien", CountryCode: "ITA"},
+			want: "Harvest season"},
+		{name: "no country code", loc: photo.Location{Country: "Italy"}, want: season.Autumn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.LocalTimeInfoWith(pd, tt.loc, seasons, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Season)
+		})
+	}
+}
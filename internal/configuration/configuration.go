@@ -1,6 +1,8 @@
 package configuration
 
 import (
+	"time"
+
 	"github.com/caarlos0/env/v6"
 	"github.com/pkg/errors"
 )
@@ -19,6 +21,70 @@ type Setup struct {
 	// ==> will simulate
 	WeatherURL    string `env:"WEATHER_URL" envDefault:"https://weather.com/historical/json"`
 	WeatherAPIKey string `env:"WEATHER_API_KEY" envDefault:"zzzzz"`
+
+	// mock (default) or http
+	WeatherProvider string `env:"WEATHER_PROVIDER" envDefault:"mock"`
+	PoiProvider     string `env:"POI_PROVIDER" envDefault:"mock"`
+
+	// 3rd party HTTP clients
+	HTTPConnectTimeout  time.Duration `env:"HTTP_CONNECT_TIMEOUT" envDefault:"5s"`
+	HTTPReadTimeout     time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"10s"`
+	HTTPTimeout         time.Duration `env:"HTTP_TIMEOUT" envDefault:"30s"`
+	HTTPMaxIdleConns    int           `env:"HTTP_MAX_IDLE_CONNS" envDefault:"20"`
+	HTTPIdleConnTimeout time.Duration `env:"HTTP_IDLE_CONN_TIMEOUT" envDefault:"90s"`
+	HTTPMaxResponseSize int64         `env:"HTTP_MAX_RESPONSE_SIZE" envDefault:"10485760"`
+
+	// trip segmentation into stops
+	TripMaxGap      time.Duration `env:"TRIP_MAX_GAP" envDefault:"3h"`
+	TripMaxDistance float64       `env:"TRIP_MAX_DISTANCE_KM" envDefault:"5"`
+
+	// density-based clustering of photo positions
+	ClusterEps       float64 `env:"CLUSTER_EPS_KM" envDefault:"1"`
+	ClusterMinPoints int     `env:"CLUSTER_MIN_POINTS" envDefault:"3"`
+
+	// photo weighting in the rankings: count, dwell or hybrid
+	RankingWeighting string        `env:"RANKING_WEIGHTING" envDefault:"hybrid"`
+	RankingMaxDwell  time.Duration `env:"RANKING_MAX_DWELL" envDefault:"2h"`
+
+	// season calendar: meteorological, astronomical or custom (SEASON_TABLE json file)
+	SeasonCalendar string `env:"SEASON_CALENDAR" envDefault:"meteorological"`
+	SeasonTable    string `env:"SEASON_TABLE"`
+
+	// holiday calendar json file extending the bundled one (countries in the file replace the bundled ones)
+	HolidayCalendar string `env:"HOLIDAY_CALENDAR"`
+
+	// language of the headings and of the place names: en, de, it or es
+	Locale string `env:"LOCALE" envDefault:"en"`
+
+	// heading score weights and selection of the top headings (0 for all) scoring at least the min score
+	ScoreSpecificity float64 `env:"SCORE_SPECIFICITY" envDefault:"0.3"`
+	ScoreConfidence  float64 `env:"SCORE_CONFIDENCE" envDefault:"0.25"`
+	ScoreLength      float64 `env:"SCORE_LENGTH" envDefault:"0.15"`
+	ScoreReadability float64 `env:"SCORE_READABILITY" envDefault:"0.15"`
+	ScoreRedundancy  float64 `env:"SCORE_REDUNDANCY" envDefault:"0.15"`
+	HeadingsTop      int     `env:"HEADINGS_TOP" envDefault:"0"`
+	HeadingsMinScore float64 `env:"HEADINGS_MIN_SCORE" envDefault:"0"`
+
+	// candidates created from each heading template and the trade-off (0-1) between their score (1)
+	// and their diversity (0)
+	DiversityOversample int     `env:"DIVERSITY_OVERSAMPLE" envDefault:"4"`
+	DiversityLambda     float64 `env:"DIVERSITY_LAMBDA" envDefault:"0.7"`
+
+	// places of interest baseline json file extending the bundled one and the number of the most distinctive
+	// places of interest mentioned by the headings
+	PlacesBaseline    string `env:"PLACES_BASELINE"`
+	PlacesDistinctive int    `env:"PLACES_DISTINCTIVE" envDefault:"2"`
+
+	// comma separated output profiles: seo-title, og-title, tweet or newsletter-subject
+	Profiles string `env:"PROFILES"`
+
+	// editorial tone of the headings: family, luxury, adventure, budget, romantic, nightlife
+	// or auto (inferred from the places of interest)
+	Tone string `env:"TONE"`
+
+	// HTTP and gRPC API servers (serve subcommand)
+	ServerAddr string `env:"SERVER_ADDR" envDefault:":8080"`
+	GRPCAddr   string `env:"GRPC_ADDR" envDefault:":9090"`
 }
 
 // Load customizes configuration based on env variables.
@@ -4,6 +4,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
 )
@@ -33,6 +34,41 @@ func TestLoad(t *testing.T) {
 
 				WeatherURL:    "https://weather.com/historical/json",
 				WeatherAPIKey: "zzzzz",
+
+				WeatherProvider: "mock",
+				PoiProvider:     "mock",
+
+				HTTPConnectTimeout:  5 * time.Second,
+				HTTPReadTimeout:     10 * time.Second,
+				HTTPTimeout:         30 * time.Second,
+				HTTPMaxIdleConns:    20,
+				HTTPIdleConnTimeout: 90 * time.Second,
+				HTTPMaxResponseSize: 10485760,
+
+				TripMaxGap:      3 * time.Hour,
+				TripMaxDistance: 5,
+
+				ClusterEps:       1,
+				ClusterMinPoints: 3,
+
+				RankingWeighting: "hybrid",
+				RankingMaxDwell:  2 * time.Hour,
+
+				SeasonCalendar: "meteorological",
+				Locale:         "en",
+
+				ScoreSpecificity: 0.3,
+				ScoreConfidence:  0.25,
+				ScoreLength:      0.15,
+				ScoreReadability: 0.15,
+				ScoreRedundancy:  0.15,
+
+				DiversityOversample: 4,
+				DiversityLambda:     0.7,
+				PlacesDistinctive:   2,
+
+				ServerAddr: ":8080",
+				GRPCAddr:   ":9090",
 			},
 			wantErr: false,
 		},
@@ -62,6 +98,41 @@ func TestLoad(t *testing.T) {
 
 				WeatherURL:    "https://weather.com/historical/json",
 				WeatherAPIKey: "zzzzz",
+
+				WeatherProvider: "mock",
+				PoiProvider:     "mock",
+
+				HTTPConnectTimeout:  5 * time.Second,
+				HTTPReadTimeout:     10 * time.Second,
+				HTTPTimeout:         30 * time.Second,
+				HTTPMaxIdleConns:    20,
+				HTTPIdleConnTimeout: 90 * time.Second,
+				HTTPMaxResponseSize: 10485760,
+
+				TripMaxGap:      3 * time.Hour,
+				TripMaxDistance: 5,
+
+				ClusterEps:       1,
+				ClusterMinPoints: 3,
+
+				RankingWeighting: "hybrid",
+				RankingMaxDwell:  2 * time.Hour,
+
+				SeasonCalendar: "meteorological",
+				Locale:         "en",
+
+				ScoreSpecificity: 0.3,
+				ScoreConfidence:  0.25,
+				ScoreLength:      0.15,
+				ScoreReadability: 0.15,
+				ScoreRedundancy:  0.15,
+
+				DiversityOversample: 4,
+				DiversityLambda:     0.7,
+				PlacesDistinctive:   2,
+
+				ServerAddr: ":8080",
+				GRPCAddr:   ":9090",
 			},
 			wantErr: false,
 		},
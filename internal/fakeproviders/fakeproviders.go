@@ -0,0 +1,311 @@
+// Package fakeproviders imitates the 3rd party location, weather and places of interest
+// endpoints for local development and end to end tests without network access.
+// Answers are deterministic and derived from the request coordinates.
+package fakeproviders
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+)
+
+// Endpoint paths.
+const (
+	HerePath    = "/here/v1/revgeocode"
+	WeatherPath = "/weather/historical/json"
+	PlacesPath  = "/places/nearbysearch/json"
+)
+
+// Config holds the fault injection knobs.
+type Config struct {
+	// Latency is added to every response.
+	Latency time.Duration
+	// RateLimited is the fraction (0-1) of requests answered with 429/Too many requests.
+	RateLimited float64
+	// Errors is the fraction (0-1) of requests answered with 500/Internal server error.
+	Errors float64
+	// Seed of the fault injection.
+	Seed int64
+}
+
+type city struct {
+	country, code, state, city string
+	lat, lon                   float64
+	landmarks                  []string
+}
+
+// cities are matched to the request coordinates by the shortest distance.
+var cities = []city{
+	{country: "Italy", code: "ITA", state: "Campania", city: "Sorrento", lat: 40.626, lon: 14.376,
+		landmarks: []string{"Piazza Tasso", "Marina Grande", "Villa Comunale"}},
+	{country: "Italy", code: "ITA", state: "Basilicata", city: "Matera", lat: 40.666, lon: 16.604,
+		landmarks: []string{"Sassi di Matera", "Matera Cathedral"}},
+	{country: "Italy", code: "ITA", state: "Lazio", city: "Rome", lat: 41.903, lon: 12.496,
+		landmarks: []string{"Colosseum", "Trevi Fountain", "Pantheon", "Piazza Navona"}},
+	{country: "Czechia", code: "CZE", state: "Prague", city: "Prague", lat: 50.075, lon: 14.438,
+		landmarks: []string{"Charles Bridge", "Old Town Square", "Prague Castle"}},
+	{country: "United Kingdom", code: "GBR", state: "England", city: "London", lat: 51.507, lon: -0.128,
+		landmarks: []string{"Tower of London", "British Museum", "Tower Bridge"}},
+	{country: "United States", code: "USA", state: "New York", city: "New York", lat: 40.713, lon: -74.006,
+		landmarks: []string{"Central Park", "Empire State Building", "Brooklyn Bridge"}},
+	{country: "United States", code: "USA", state: "Nevada", city: "Las Vegas", lat: 36.170, lon: -115.140,
+		landmarks: []string{"Fremont Street", "Bellagio Fountains"}},
+	{country: "Australia", code: "AUS", state: "New South Wales", city: "Sydney", lat: -33.869, lon: 151.209,
+		landmarks: []string{"Sydney Opera House", "Bondi Beach"}},
+	{country: "South Africa", code: "ZAF", state: "Western Cape", city: "Cape Town", lat: -33.925, lon: 18.424,
+		landmarks: []string{"Table Mountain", "V&A Waterfront"}},
+	{country: "Japan", code: "JPN", state: "Tokyo", city: "Tokyo", lat: 35.676, lon: 139.650,
+		landmarks: []string{"Senso-ji", "Shibuya Crossing", "Tokyo Tower"}},
+}
+
+var weathers = []string{"rainy", "wet", "boiling hot", "sunny", "stormy", "drizzly", "hazy", "scorching",
+	"hot", "unbearably hot", "miserably cold"}
+
+type server struct {
+	cfg Config
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// New creates the fake providers handler.
+func New(cfg Config) http.Handler {
+	s := &server{
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(HerePath, s.inject("apiKey", s.revgeocode))
+	mux.HandleFunc(WeatherPath, s.inject("key", s.weather))
+	mux.HandleFunc(PlacesPath, s.inject("key", s.places))
+	return mux
+}
+
+// Configure points the 3rd party clients configuration to the fake providers
+// running at baseURL.
+func Configure(cfg conf.Setup, baseURL string) conf.Setup {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	cfg.HereURL = baseURL + HerePath
+	cfg.WeatherURL = baseURL + WeatherPath
+	cfg.GooglePlacesURL = baseURL + PlacesPath
+	cfg.WeatherProvider = client.HTTPProvider
+	cfg.PoiProvider = client.HTTPProvider
+	return cfg
+}
+
+// Env lists the env variables pointing the 3rd party clients to the fake providers.
+func Env(baseURL string) []string {
+	cfg := Configure(conf.Setup{}, baseURL)
+	return []string{
+		"HERE_URL=" + cfg.HereURL,
+		"WEATHER_URL=" + cfg.WeatherURL,
+		"GOOGLE_PLACES_URL=" + cfg.GooglePlacesURL,
+		"WEATHER_PROVIDER=" + cfg.WeatherProvider,
+		"POI_PROVIDER=" + cfg.PoiProvider,
+	}
+}
+
+// inject adds the latency, checks the API key and injects failures.
+func (s *server) inject(keyParam string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.Latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(s.cfg.Latency):
+			}
+		}
+
+		if r.URL.Query().Get(keyParam) == "" {
+			writeError(w, http.StatusUnauthorized, "missing API key")
+			return
+		}
+
+		s.mu.Lock()
+		n := s.rnd.Float64()
+		s.mu.Unlock()
+
+		switch {
+		case n < s.cfg.RateLimited:
+			w.Header().Set("Retry-After", "1")
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		case n < s.cfg.RateLimited+s.cfg.Errors:
+			writeError(w, http.StatusInternalServerError, "injected failure")
+		default:
+			h(w, r)
+		}
+	}
+}
+
+func (s *server) revgeocode(w http.ResponseWriter, r *http.Request) {
+	lat, lon, err := parseLatLon(r.URL.Query().Get("at"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c := nearest(lat, lon)
+
+	writeJSON(w, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{
+				"title":      fmt.Sprintf("%s, %s", c.city, c.country),
+				"resultType": "locality",
+				"address": map[string]string{
+					"label":       fmt.Sprintf("%s, %s, %s", c.city, c.state, c.country),
+					"countryCode": c.code,
+					"countryName": c.country,
+					"state":       c.state,
+					"city":        c.city,
+				},
+				"position": map[string]float64{"lat": lat, "lng": lon},
+			},
+		},
+	})
+}
+
+func (s *server) weather(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, lon, err := parseLatLon(q.Get("lat") + "," + q.Get("lon"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	dt, err := strconv.ParseInt(q.Get("dt"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid dt")
+		return
+	}
+
+	// the same weather for the whole day
+	day := time.Unix(dt, 0).UTC().Format("2006-01-02")
+	wt := weathers[hash(lat, lon, day)%uint64(len(weathers))]
+
+	writeJSON(w, map[string]interface{}{
+		"lat": lat,
+		"lon": lon,
+		"dt":  dt,
+		"weather": []interface{}{
+			map[string]string{"main": wt, "description": wt},
+		},
+	})
+}
+
+func (s *server) places(w http.ResponseWriter, r *http.Request) {
+	lat, lon, err := parseLatLon(r.URL.Query().Get("location"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	types := make([]string, 0, len(client.PlaceTypes))
+	for t := range client.PlaceTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	results := []interface{}{}
+	for _, t := range types {
+		name := client.PlaceTypes[t]
+		n := int(hash(lat, lon, t) % 10)
+		for i := 1; i <= n; i++ {
+			results = append(results, place(lat, lon, fmt.Sprintf("%s %d", name, i),
+				[]string{t, "point_of_interest", "establishment"}))
+		}
+	}
+	// one or two landmarks of the city nearby.
+	landmarks := nearest(lat, lon).landmarks
+	for i := 0; i < len(landmarks) && i < 2; i++ {
+		name := landmarks[(hash(lat, lon, "landmark")+uint64(i))%uint64(len(landmarks))]
+		results = append(results, place(lat, lon, name, []string{"tourist_attraction", "point_of_interest"}))
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"results": results,
+		"status":  "OK",
+	})
+}
+
+// place is a nearby search result located near the request coordinates. Requests close to each other
+// (see hash) get the places at the same location with the same number of ratings.
+func place(lat, lon float64, name string, types []string) map[string]interface{} {
+	h := hash(lat, lon, name)
+	// within about 300m of the rounded request coordinates.
+	dLat := float64(h%600)/100000 - 0.003
+	dLon := float64(h/600%600)/100000 - 0.003
+
+	return map[string]interface{}{
+		"name": name,
+		"geometry": map[string]interface{}{
+			"location": map[string]float64{
+				"lat": math.Round(lat*100)/100 + dLat,
+				"lng": math.Round(lon*100)/100 + dLon,
+			},
+		},
+		"rating":             1 + float64(h%40)/10,
+		"user_ratings_total": int(h % 50000),
+		"types":              types,
+	}
+}
+
+func parseLatLon(s string) (float64, float64, error) {
+	ll := strings.Split(s, ",")
+	if len(ll) != 2 {
+		return 0, 0, fmt.Errorf("invalid coordinates %q", s)
+	}
+	lat, err := strconv.ParseFloat(ll[0], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid latitude %q", ll[0])
+	}
+	lon, err := strconv.ParseFloat(ll[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid longitude %q", ll[1])
+	}
+	return lat, lon, nil
+}
+
+func nearest(lat, lon float64) city {
+	n := cities[0]
+	min := math.MaxFloat64
+	for _, c := range cities {
+		d := math.Pow(c.lat-lat, 2) + math.Pow(c.lon-lon, 2)
+		if d < min {
+			n, min = c, d
+		}
+	}
+	return n
+}
+
+// hash is based on the coordinates rounded to about 1km, so that photos taken
+// close to each other get the same answers.
+func hash(lat, lon float64, s string) uint64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%.2f,%.2f,%s", lat, lon, s)
+	return h.Sum64()
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": status,
+		"title":  msg,
+	})
+}
@@ -0,0 +1,153 @@
+// +build unit_tests
+
+package fakeproviders_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/fakeproviders"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+var pd = photo.Data{
+	ArticleID: "article1",
+	ID:        1,
+	Date:      "2019-10-27T13:27:58Z",
+	LatLon: photo.LatLon{
+		Latitude:  "40.647863",
+		Longitude: "14.366958",
+	},
+}
+
+var keys = conf.Setup{
+	HereAPIKey:         "xxxxx",
+	GooglePlacesAPIKey: "yyyyy",
+	WeatherAPIKey:      "zzzzz",
+}
+
+func TestFakeProviders_Deterministic(t *testing.T) {
+	ts := httptest.NewServer(fakeproviders.New(fakeproviders.Config{}))
+	defer ts.Close()
+
+	cs, err := client.BuildClients(fakeproviders.Configure(keys, ts.URL))
+	require.NoError(t, err)
+
+	loc, err := cs.Addresses.Locate(context.Background(), pd)
+	require.NoError(t, err)
+	require.Equal(t, photo.Location{Country: "Italy", CountryCode: "ITA", State: "Campania", City: "Sorrento"},
+		loc)
+
+	w, err := cs.Weather.Weather(context.Background(), pd)
+	require.NoError(t, err)
+	require.NotEmpty(t, w)
+
+	pois, err := cs.POI.PlacesOfInterest(context.Background(), pd)
+	require.NoError(t, err)
+	require.NotEmpty(t, pois)
+
+	_, landmarks, err := cs.POI.(client.LandmarkPoi).PlacesAndLandmarks(context.Background(), pd)
+	require.NoError(t, err)
+	require.NotEmpty(t, landmarks)
+	for _, l := range landmarks {
+		require.NotEmpty(t, l.Name)
+		require.Less(t, l.Distance, 1000.0, l.Name)
+	}
+	// the landmarks of Sorrento.
+	require.Contains(t, []string{"Piazza Tasso", "Marina Grande", "Villa Comunale"}, landmarks[len(landmarks)-1].Name)
+
+	// the same answers for the same coordinates
+	for i := 0; i < 3; i++ {
+		w2, err := cs.Weather.Weather(context.Background(), pd)
+		require.NoError(t, err)
+		require.Equal(t, w, w2)
+
+		pois2, err := cs.POI.PlacesOfInterest(context.Background(), pd)
+		require.NoError(t, err)
+		require.Equal(t, pois, pois2)
+
+		_, landmarks2, err := cs.POI.(client.LandmarkPoi).PlacesAndLandmarks(context.Background(), pd)
+		require.NoError(t, err)
+		require.Equal(t, landmarks, landmarks2)
+	}
+}
+
+func TestFakeProviders_FaultInjection(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  fakeproviders.Config
+		keys conf.Setup
+		want error
+	}{
+		{
+			name: "rate limited",
+			cfg:  fakeproviders.Config{RateLimited: 1},
+			keys: keys,
+			want: client.ErrRateLimited,
+		},
+		{
+			name: "errors",
+			cfg:  fakeproviders.Config{Errors: 1},
+			keys: keys,
+			want: client.ErrProviderUnavailable,
+		},
+		{
+			name: "missing API key",
+			keys: conf.Setup{},
+			want: client.ErrUnauthorized,
+		},
+		{
+			name: "latency",
+			cfg:  fakeproviders.Config{Latency: time.Second},
+			keys: conf.Setup{
+				HereAPIKey:      "xxxxx",
+				HTTPReadTimeout: 50 * time.Millisecond,
+			},
+			want: client.ErrProviderUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(fakeproviders.New(tt.cfg))
+			defer ts.Close()
+
+			cs, err := client.BuildClients(fakeproviders.Configure(tt.keys, ts.URL))
+			require.NoError(t, err)
+
+			_, err = cs.Addresses.Locate(context.Background(), pd)
+			require.Error(t, err)
+			require.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestFakeProviders_EndToEnd(t *testing.T) {
+	ts := httptest.NewServer(fakeproviders.New(fakeproviders.Config{RateLimited: 0.2, Seed: 1}))
+	defer ts.Close()
+
+	cs, err := client.BuildClients(fakeproviders.Configure(keys, ts.URL))
+	require.NoError(t, err)
+
+	as := service.ArticleService{
+		Clients: cs,
+		Retry:   service.RetryPolicy{Attempts: 10, Backoff: time.Millisecond},
+	}
+
+	photoL, err := service.ReadPhotoData(context.Background(), "../../data4testing/article1.csv.test")
+	require.NoError(t, err)
+
+	article, err := as.ProcessArticle(context.Background(), "article1", photoL)
+	require.NoError(t, err)
+	require.Equal(t, "Italy", article.Summary.Country)
+	require.Equal(t, "Sorrento", article.Summary.City)
+	require.Equal(t, len(photoL), article.Summary.Locations)
+	require.Equal(t, len(photoL), article.Summary.Weathers)
+	require.Equal(t, len(photoL), article.Summary.Pois)
+	require.Len(t, article.Headings, 8)
+}
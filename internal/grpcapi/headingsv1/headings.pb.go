@@ -0,0 +1,1385 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: headings/v1/headings.proto
+
+package headingsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Photo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RFC3339 or "2006-01-02 15:04:05"
+	Date      string  `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Latitude  float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *Photo) Reset() {
+	*x = Photo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Photo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Photo) ProtoMessage() {}
+
+func (x *Photo) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Photo.ProtoReflect.Descriptor instead.
+func (*Photo) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Photo) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Photo) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Photo) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Article struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Photos []*Photo `protobuf:"bytes,2,rep,name=photos,proto3" json:"photos,omitempty"`
+}
+
+func (x *Article) Reset() {
+	*x = Article{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Article) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Article) ProtoMessage() {}
+
+func (x *Article) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Article.ProtoReflect.Descriptor instead.
+func (*Article) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Article) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Article) GetPhotos() []*Photo {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+type Summary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Country         string   `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	City            string   `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Weather         string   `protobuf:"bytes,3,opt,name=weather,proto3" json:"weather,omitempty"`
+	Weekday         string   `protobuf:"bytes,4,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	Month           string   `protobuf:"bytes,5,opt,name=month,proto3" json:"month,omitempty"`
+	Season          string   `protobuf:"bytes,6,opt,name=season,proto3" json:"season,omitempty"`
+	PlaceOfInterest string   `protobuf:"bytes,7,opt,name=place_of_interest,json=placeOfInterest,proto3" json:"place_of_interest,omitempty"`
+	Photos          int32    `protobuf:"varint,8,opt,name=photos,proto3" json:"photos,omitempty"`
+	Locations       int32    `protobuf:"varint,9,opt,name=locations,proto3" json:"locations,omitempty"`
+	Weathers        int32    `protobuf:"varint,10,opt,name=weathers,proto3" json:"weathers,omitempty"`
+	Pois            int32    `protobuf:"varint,11,opt,name=pois,proto3" json:"pois,omitempty"`
+	Errors          []string `protobuf:"bytes,12,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Summary) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Summary) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Summary) GetWeather() string {
+	if x != nil {
+		return x.Weather
+	}
+	return ""
+}
+
+func (x *Summary) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *Summary) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *Summary) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *Summary) GetPlaceOfInterest() string {
+	if x != nil {
+		return x.PlaceOfInterest
+	}
+	return ""
+}
+
+func (x *Summary) GetPhotos() int32 {
+	if x != nil {
+		return x.Photos
+	}
+	return 0
+}
+
+func (x *Summary) GetLocations() int32 {
+	if x != nil {
+		return x.Locations
+	}
+	return 0
+}
+
+func (x *Summary) GetWeathers() int32 {
+	if x != nil {
+		return x.Weathers
+	}
+	return 0
+}
+
+func (x *Summary) GetPois() int32 {
+	if x != nil {
+		return x.Pois
+	}
+	return 0
+}
+
+func (x *Summary) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// ScoredHeading is a heading with its score (0-1) and the score components.
+type ScoredHeading struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Heading string  `protobuf:"bytes,1,opt,name=heading,proto3" json:"heading,omitempty"`
+	Score   float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// specificity of the information mentioned in the heading, eg the city rather than the country.
+	Specificity float64 `protobuf:"fixed64,3,opt,name=specificity,proto3" json:"specificity,omitempty"`
+	// confidence is the mean share of the photos with the information mentioned in the heading.
+	Confidence  float64 `protobuf:"fixed64,4,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Length      float64 `protobuf:"fixed64,5,opt,name=length,proto3" json:"length,omitempty"`
+	Readability float64 `protobuf:"fixed64,6,opt,name=readability,proto3" json:"readability,omitempty"`
+	// redundancy is the repetition of words within the heading or of a better-ranked heading.
+	Redundancy float64 `protobuf:"fixed64,7,opt,name=redundancy,proto3" json:"redundancy,omitempty"`
+}
+
+func (x *ScoredHeading) Reset() {
+	*x = ScoredHeading{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoredHeading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoredHeading) ProtoMessage() {}
+
+func (x *ScoredHeading) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoredHeading.ProtoReflect.Descriptor instead.
+func (*ScoredHeading) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScoredHeading) GetHeading() string {
+	if x != nil {
+		return x.Heading
+	}
+	return ""
+}
+
+func (x *ScoredHeading) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ScoredHeading) GetSpecificity() float64 {
+	if x != nil {
+		return x.Specificity
+	}
+	return 0
+}
+
+func (x *ScoredHeading) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ScoredHeading) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *ScoredHeading) GetReadability() float64 {
+	if x != nil {
+		return x.Readability
+	}
+	return 0
+}
+
+func (x *ScoredHeading) GetRedundancy() float64 {
+	if x != nil {
+		return x.Redundancy
+	}
+	return 0
+}
+
+type SuggestHeadingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Article *Article `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`
+}
+
+func (x *SuggestHeadingsRequest) Reset() {
+	*x = SuggestHeadingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestHeadingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestHeadingsRequest) ProtoMessage() {}
+
+func (x *SuggestHeadingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestHeadingsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestHeadingsRequest) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SuggestHeadingsRequest) GetArticle() *Article {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+type SuggestHeadingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// headings are in the order they were selected in.
+	Headings []string `protobuf:"bytes,2,rep,name=headings,proto3" json:"headings,omitempty"`
+	Summary  *Summary `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	// scores of the headings, in the same order.
+	Scores []*ScoredHeading `protobuf:"bytes,4,rep,name=scores,proto3" json:"scores,omitempty"`
+}
+
+func (x *SuggestHeadingsResponse) Reset() {
+	*x = SuggestHeadingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuggestHeadingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestHeadingsResponse) ProtoMessage() {}
+
+func (x *SuggestHeadingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestHeadingsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestHeadingsResponse) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SuggestHeadingsResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SuggestHeadingsResponse) GetHeadings() []string {
+	if x != nil {
+		return x.Headings
+	}
+	return nil
+}
+
+func (x *SuggestHeadingsResponse) GetSummary() *Summary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *SuggestHeadingsResponse) GetScores() []*ScoredHeading {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+type ProcessArticlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Articles []*Article `protobuf:"bytes,1,rep,name=articles,proto3" json:"articles,omitempty"`
+}
+
+func (x *ProcessArticlesRequest) Reset() {
+	*x = ProcessArticlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessArticlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessArticlesRequest) ProtoMessage() {}
+
+func (x *ProcessArticlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessArticlesRequest.ProtoReflect.Descriptor instead.
+func (*ProcessArticlesRequest) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProcessArticlesRequest) GetArticles() []*Article {
+	if x != nil {
+		return x.Articles
+	}
+	return nil
+}
+
+type ProcessArticlesEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Article string `protobuf:"bytes,1,opt,name=article,proto3" json:"article,omitempty"`
+	// Types that are assignable to Event:
+	//	*ProcessArticlesEvent_Location
+	//	*ProcessArticlesEvent_Weather
+	//	*ProcessArticlesEvent_Poi
+	//	*ProcessArticlesEvent_Error
+	//	*ProcessArticlesEvent_Headings
+	Event isProcessArticlesEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *ProcessArticlesEvent) Reset() {
+	*x = ProcessArticlesEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProcessArticlesEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessArticlesEvent) ProtoMessage() {}
+
+func (x *ProcessArticlesEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessArticlesEvent.ProtoReflect.Descriptor instead.
+func (*ProcessArticlesEvent) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProcessArticlesEvent) GetArticle() string {
+	if x != nil {
+		return x.Article
+	}
+	return ""
+}
+
+func (m *ProcessArticlesEvent) GetEvent() isProcessArticlesEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ProcessArticlesEvent) GetLocation() *LocationEvent {
+	if x, ok := x.GetEvent().(*ProcessArticlesEvent_Location); ok {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *ProcessArticlesEvent) GetWeather() *WeatherEvent {
+	if x, ok := x.GetEvent().(*ProcessArticlesEvent_Weather); ok {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *ProcessArticlesEvent) GetPoi() *PoiEvent {
+	if x, ok := x.GetEvent().(*ProcessArticlesEvent_Poi); ok {
+		return x.Poi
+	}
+	return nil
+}
+
+func (x *ProcessArticlesEvent) GetError() *ErrorEvent {
+	if x, ok := x.GetEvent().(*ProcessArticlesEvent_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *ProcessArticlesEvent) GetHeadings() *HeadingsEvent {
+	if x, ok := x.GetEvent().(*ProcessArticlesEvent_Headings); ok {
+		return x.Headings
+	}
+	return nil
+}
+
+type isProcessArticlesEvent_Event interface {
+	isProcessArticlesEvent_Event()
+}
+
+type ProcessArticlesEvent_Location struct {
+	Location *LocationEvent `protobuf:"bytes,2,opt,name=location,proto3,oneof"`
+}
+
+type ProcessArticlesEvent_Weather struct {
+	Weather *WeatherEvent `protobuf:"bytes,3,opt,name=weather,proto3,oneof"`
+}
+
+type ProcessArticlesEvent_Poi struct {
+	Poi *PoiEvent `protobuf:"bytes,4,opt,name=poi,proto3,oneof"`
+}
+
+type ProcessArticlesEvent_Error struct {
+	Error *ErrorEvent `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type ProcessArticlesEvent_Headings struct {
+	Headings *HeadingsEvent `protobuf:"bytes,6,opt,name=headings,proto3,oneof"`
+}
+
+func (*ProcessArticlesEvent_Location) isProcessArticlesEvent_Event() {}
+
+func (*ProcessArticlesEvent_Weather) isProcessArticlesEvent_Event() {}
+
+func (*ProcessArticlesEvent_Poi) isProcessArticlesEvent_Event() {}
+
+func (*ProcessArticlesEvent_Error) isProcessArticlesEvent_Event() {}
+
+func (*ProcessArticlesEvent_Headings) isProcessArticlesEvent_Event() {}
+
+type LocationEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PhotoId int32  `protobuf:"varint,1,opt,name=photo_id,json=photoId,proto3" json:"photo_id,omitempty"`
+	Country string `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	City    string `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+}
+
+func (x *LocationEvent) Reset() {
+	*x = LocationEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationEvent) ProtoMessage() {}
+
+func (x *LocationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationEvent.ProtoReflect.Descriptor instead.
+func (*LocationEvent) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LocationEvent) GetPhotoId() int32 {
+	if x != nil {
+		return x.PhotoId
+	}
+	return 0
+}
+
+func (x *LocationEvent) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *LocationEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type WeatherEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PhotoId int32  `protobuf:"varint,1,opt,name=photo_id,json=photoId,proto3" json:"photo_id,omitempty"`
+	Weather string `protobuf:"bytes,2,opt,name=weather,proto3" json:"weather,omitempty"`
+	Weekday string `protobuf:"bytes,3,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	Month   string `protobuf:"bytes,4,opt,name=month,proto3" json:"month,omitempty"`
+	Season  string `protobuf:"bytes,5,opt,name=season,proto3" json:"season,omitempty"`
+}
+
+func (x *WeatherEvent) Reset() {
+	*x = WeatherEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeatherEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeatherEvent) ProtoMessage() {}
+
+func (x *WeatherEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeatherEvent.ProtoReflect.Descriptor instead.
+func (*WeatherEvent) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WeatherEvent) GetPhotoId() int32 {
+	if x != nil {
+		return x.PhotoId
+	}
+	return 0
+}
+
+func (x *WeatherEvent) GetWeather() string {
+	if x != nil {
+		return x.Weather
+	}
+	return ""
+}
+
+func (x *WeatherEvent) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *WeatherEvent) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *WeatherEvent) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+type PoiEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PhotoId          int32            `protobuf:"varint,1,opt,name=photo_id,json=photoId,proto3" json:"photo_id,omitempty"`
+	PlacesOfInterest map[string]int32 `protobuf:"bytes,2,rep,name=places_of_interest,json=placesOfInterest,proto3" json:"places_of_interest,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *PoiEvent) Reset() {
+	*x = PoiEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoiEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoiEvent) ProtoMessage() {}
+
+func (x *PoiEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoiEvent.ProtoReflect.Descriptor instead.
+func (*PoiEvent) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PoiEvent) GetPhotoId() int32 {
+	if x != nil {
+		return x.PhotoId
+	}
+	return 0
+}
+
+func (x *PoiEvent) GetPlacesOfInterest() map[string]int32 {
+	if x != nil {
+		return x.PlacesOfInterest
+	}
+	return nil
+}
+
+type ErrorEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ErrorEvent) Reset() {
+	*x = ErrorEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorEvent) ProtoMessage() {}
+
+func (x *ErrorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorEvent.ProtoReflect.Descriptor instead.
+func (*ErrorEvent) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ErrorEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// HeadingsEvent is the last event of an article.
+type HeadingsEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headings []string `protobuf:"bytes,1,rep,name=headings,proto3" json:"headings,omitempty"`
+	Summary  *Summary `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	// set when no headings could be suggested.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// scores of the headings, in the same order.
+	Scores []*ScoredHeading `protobuf:"bytes,4,rep,name=scores,proto3" json:"scores,omitempty"`
+}
+
+func (x *HeadingsEvent) Reset() {
+	*x = HeadingsEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_headings_v1_headings_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeadingsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadingsEvent) ProtoMessage() {}
+
+func (x *HeadingsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_headings_v1_headings_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadingsEvent.ProtoReflect.Descriptor instead.
+func (*HeadingsEvent) Descriptor() ([]byte, []int) {
+	return file_headings_v1_headings_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HeadingsEvent) GetHeadings() []string {
+	if x != nil {
+		return x.Headings
+	}
+	return nil
+}
+
+func (x *HeadingsEvent) GetSummary() *Summary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *HeadingsEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *HeadingsEvent) GetScores() []*ScoredHeading {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+var File_headings_v1_headings_proto protoreflect.FileDescriptor
+
+var file_headings_v1_headings_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x68, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x55, 0x0a, 0x05, 0x50, 0x68, 0x6f,
+	0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x22, 0x49, 0x0a, 0x07, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x2a, 0x0a, 0x06, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68,
+	0x6f, 0x74, 0x6f, 0x52, 0x06, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x73, 0x22, 0xc3, 0x02, 0x0a, 0x07,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e,
+	0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x5f, 0x6f, 0x66, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x4f, 0x66, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x77, 0x65, 0x61,
+	0x74, 0x68, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x69, 0x73, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x69, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x22, 0xdb, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x69,
+	0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66,
+	0x69, 0x63, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x20, 0x0a,
+	0x0b, 0x72, 0x65, 0x61, 0x64, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x64, 0x75, 0x6e, 0x64, 0x61, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x72, 0x65, 0x64, 0x75, 0x6e, 0x64, 0x61, 0x6e, 0x63, 0x79, 0x22,
+	0x48, 0x0a, 0x16, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65,
+	0x52, 0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x17, 0x53, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x16, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x73, 0x22, 0xc0, 0x02, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x03, 0x70, 0x6f, 0x69,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x69, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x03, 0x70, 0x6f, 0x69, 0x12, 0x2f, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x38, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x42,
+	0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x58, 0x0a, 0x0d, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x68, 0x6f,
+	0x74, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x68, 0x6f,
+	0x74, 0x6f, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69,
+	0x74, 0x79, 0x22, 0x8b, 0x01, 0x0a, 0x0c, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x65, 0x65, 0x6b,
+	0x64, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x65, 0x65, 0x6b, 0x64,
+	0x61, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0xc5, 0x01, 0x0a, 0x08, 0x50, 0x6f, 0x69, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x70, 0x68, 0x6f, 0x74, 0x6f, 0x49, 0x64, 0x12, 0x59, 0x0a, 0x12, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x73, 0x5f, 0x6f, 0x66, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x6f, 0x69, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x6c, 0x61, 0x63,
+	0x65, 0x73, 0x4f, 0x66, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x10, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x4f, 0x66, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x1a, 0x43, 0x0a, 0x15, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x73, 0x4f, 0x66, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x26, 0x0a, 0x0a, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0xa5, 0x01, 0x0a, 0x0d, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2e,
+	0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x32, 0xcb, 0x01, 0x0a, 0x0e, 0x48, 0x65, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x53,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x23,
+	0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x67,
+	0x67, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5b, 0x0a, 0x0f, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x68,
+	0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x59, 0x5a, 0x57, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x61, 0x6d, 0x61, 0x72, 0x61, 0x6b, 0x61, 0x75, 0x66, 0x6c,
+	0x65, 0x72, 0x2f, 0x74, 0x72, 0x61, 0x76, 0x65, 0x6c, 0x2d, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c,
+	0x65, 0x2d, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x2f, 0x68, 0x65, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x76, 0x31, 0x3b, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_headings_v1_headings_proto_rawDescOnce sync.Once
+	file_headings_v1_headings_proto_rawDescData = file_headings_v1_headings_proto_rawDesc
+)
+
+func file_headings_v1_headings_proto_rawDescGZIP() []byte {
+	file_headings_v1_headings_proto_rawDescOnce.Do(func() {
+		file_headings_v1_headings_proto_rawDescData = protoimpl.X.CompressGZIP(file_headings_v1_headings_proto_rawDescData)
+	})
+	return file_headings_v1_headings_proto_rawDescData
+}
+
+var file_headings_v1_headings_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_headings_v1_headings_proto_goTypes = []interface{}{
+	(*Photo)(nil),                   // 0: headings.v1.Photo
+	(*Article)(nil),                 // 1: headings.v1.Article
+	(*Summary)(nil),                 // 2: headings.v1.Summary
+	(*ScoredHeading)(nil),           // 3: headings.v1.ScoredHeading
+	(*SuggestHeadingsRequest)(nil),  // 4: headings.v1.SuggestHeadingsRequest
+	(*SuggestHeadingsResponse)(nil), // 5: headings.v1.SuggestHeadingsResponse
+	(*ProcessArticlesRequest)(nil),  // 6: headings.v1.ProcessArticlesRequest
+	(*ProcessArticlesEvent)(nil),    // 7: headings.v1.ProcessArticlesEvent
+	(*LocationEvent)(nil),           // 8: headings.v1.LocationEvent
+	(*WeatherEvent)(nil),            // 9: headings.v1.WeatherEvent
+	(*PoiEvent)(nil),                // 10: headings.v1.PoiEvent
+	(*ErrorEvent)(nil),              // 11: headings.v1.ErrorEvent
+	(*HeadingsEvent)(nil),           // 12: headings.v1.HeadingsEvent
+	nil,                             // 13: headings.v1.PoiEvent.PlacesOfInterestEntry
+}
+var file_headings_v1_headings_proto_depIdxs = []int32{
+	0,  // 0: headings.v1.Article.photos:type_name -> headings.v1.Photo
+	1,  // 1: headings.v1.SuggestHeadingsRequest.article:type_name -> headings.v1.Article
+	2,  // 2: headings.v1.SuggestHeadingsResponse.summary:type_name -> headings.v1.Summary
+	3,  // 3: headings.v1.SuggestHeadingsResponse.scores:type_name -> headings.v1.ScoredHeading
+	1,  // 4: headings.v1.ProcessArticlesRequest.articles:type_name -> headings.v1.Article
+	8,  // 5: headings.v1.ProcessArticlesEvent.location:type_name -> headings.v1.LocationEvent
+	9,  // 6: headings.v1.ProcessArticlesEvent.weather:type_name -> headings.v1.WeatherEvent
+	10, // 7: headings.v1.ProcessArticlesEvent.poi:type_name -> headings.v1.PoiEvent
+	11, // 8: headings.v1.ProcessArticlesEvent.error:type_name -> headings.v1.ErrorEvent
+	12, // 9: headings.v1.ProcessArticlesEvent.headings:type_name -> headings.v1.HeadingsEvent
+	13, // 10: headings.v1.PoiEvent.places_of_interest:type_name -> headings.v1.PoiEvent.PlacesOfInterestEntry
+	2,  // 11: headings.v1.HeadingsEvent.summary:type_name -> headings.v1.Summary
+	3,  // 12: headings.v1.HeadingsEvent.scores:type_name -> headings.v1.ScoredHeading
+	4,  // 13: headings.v1.HeadingService.SuggestHeadings:input_type -> headings.v1.SuggestHeadingsRequest
+	6,  // 14: headings.v1.HeadingService.ProcessArticles:input_type -> headings.v1.ProcessArticlesRequest
+	5,  // 15: headings.v1.HeadingService.SuggestHeadings:output_type -> headings.v1.SuggestHeadingsResponse
+	7,  // 16: headings.v1.HeadingService.ProcessArticles:output_type -> headings.v1.ProcessArticlesEvent
+	15, // [15:17] is the sub-list for method output_type
+	13, // [13:15] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_headings_v1_headings_proto_init() }
+func file_headings_v1_headings_proto_init() {
+	if File_headings_v1_headings_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_headings_v1_headings_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Photo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Article); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Summary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoredHeading); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestHeadingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuggestHeadingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessArticlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessArticlesEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocationEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WeatherEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoiEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_headings_v1_headings_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeadingsEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_headings_v1_headings_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*ProcessArticlesEvent_Location)(nil),
+		(*ProcessArticlesEvent_Weather)(nil),
+		(*ProcessArticlesEvent_Poi)(nil),
+		(*ProcessArticlesEvent_Error)(nil),
+		(*ProcessArticlesEvent_Headings)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_headings_v1_headings_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_headings_v1_headings_proto_goTypes,
+		DependencyIndexes: file_headings_v1_headings_proto_depIdxs,
+		MessageInfos:      file_headings_v1_headings_proto_msgTypes,
+	}.Build()
+	File_headings_v1_headings_proto = out.File
+	file_headings_v1_headings_proto_rawDesc = nil
+	file_headings_v1_headings_proto_goTypes = nil
+	file_headings_v1_headings_proto_depIdxs = nil
+}
@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package headingsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// HeadingServiceClient is the client API for HeadingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HeadingServiceClient interface {
+	// SuggestHeadings suggests headings for one article.
+	SuggestHeadings(ctx context.Context, in *SuggestHeadingsRequest, opts ...grpc.CallOption) (*SuggestHeadingsResponse, error)
+	// ProcessArticles processes the articles concurrently and streams the additional
+	// photo information as it is retrieved, followed by the headings of each article.
+	ProcessArticles(ctx context.Context, in *ProcessArticlesRequest, opts ...grpc.CallOption) (HeadingService_ProcessArticlesClient, error)
+}
+
+type headingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHeadingServiceClient(cc grpc.ClientConnInterface) HeadingServiceClient {
+	return &headingServiceClient{cc}
+}
+
+func (c *headingServiceClient) SuggestHeadings(ctx context.Context, in *SuggestHeadingsRequest, opts ...grpc.CallOption) (*SuggestHeadingsResponse, error) {
+	out := new(SuggestHeadingsResponse)
+	err := c.cc.Invoke(ctx, "/headings.v1.HeadingService/SuggestHeadings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *headingServiceClient) ProcessArticles(ctx context.Context, in *ProcessArticlesRequest, opts ...grpc.CallOption) (HeadingService_ProcessArticlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &HeadingService_ServiceDesc.Streams[0], "/headings.v1.HeadingService/ProcessArticles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &headingServiceProcessArticlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HeadingService_ProcessArticlesClient interface {
+	Recv() (*ProcessArticlesEvent, error)
+	grpc.ClientStream
+}
+
+type headingServiceProcessArticlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *headingServiceProcessArticlesClient) Recv() (*ProcessArticlesEvent, error) {
+	m := new(ProcessArticlesEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HeadingServiceServer is the server API for HeadingService service.
+// All implementations must embed UnimplementedHeadingServiceServer
+// for forward compatibility
+type HeadingServiceServer interface {
+	// SuggestHeadings suggests headings for one article.
+	SuggestHeadings(context.Context, *SuggestHeadingsRequest) (*SuggestHeadingsResponse, error)
+	// ProcessArticles processes the articles concurrently and streams the additional
+	// photo information as it is retrieved, followed by the headings of each article.
+	ProcessArticles(*ProcessArticlesRequest, HeadingService_ProcessArticlesServer) error
+	mustEmbedUnimplementedHeadingServiceServer()
+}
+
+// UnimplementedHeadingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHeadingServiceServer struct {
+}
+
+func (UnimplementedHeadingServiceServer) SuggestHeadings(context.Context, *SuggestHeadingsRequest) (*SuggestHeadingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestHeadings not implemented")
+}
+func (UnimplementedHeadingServiceServer) ProcessArticles(*ProcessArticlesRequest, HeadingService_ProcessArticlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessArticles not implemented")
+}
+func (UnimplementedHeadingServiceServer) mustEmbedUnimplementedHeadingServiceServer() {}
+
+// UnsafeHeadingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HeadingServiceServer will
+// result in compilation errors.
+type UnsafeHeadingServiceServer interface {
+	mustEmbedUnimplementedHeadingServiceServer()
+}
+
+func RegisterHeadingServiceServer(s grpc.ServiceRegistrar, srv HeadingServiceServer) {
+	s.RegisterService(&HeadingService_ServiceDesc, srv)
+}
+
+func _HeadingService_SuggestHeadings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestHeadingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HeadingServiceServer).SuggestHeadings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/headings.v1.HeadingService/SuggestHeadings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HeadingServiceServer).SuggestHeadings(ctx, req.(*SuggestHeadingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HeadingService_ProcessArticles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProcessArticlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HeadingServiceServer).ProcessArticles(m, &headingServiceProcessArticlesServer{stream})
+}
+
+type HeadingService_ProcessArticlesServer interface {
+	Send(*ProcessArticlesEvent) error
+	grpc.ServerStream
+}
+
+type headingServiceProcessArticlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *headingServiceProcessArticlesServer) Send(m *ProcessArticlesEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// HeadingService_ServiceDesc is the grpc.ServiceDesc for HeadingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HeadingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "headings.v1.HeadingService",
+	HandlerType: (*HeadingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SuggestHeadings",
+			Handler:    _HeadingService_SuggestHeadings_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessArticles",
+			Handler:       _HeadingService_ProcessArticles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "headings/v1/headings.proto",
+}
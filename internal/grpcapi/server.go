@@ -0,0 +1,217 @@
+package grpcapi
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/tamarakaufler/travel-article-headings/internal/grpcapi/headingsv1"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+// Server implements the gRPC HeadingService.
+type Server struct {
+	pb.UnimplementedHeadingServiceServer
+
+	service service.ArticleService
+}
+
+// New is a Server constructor.
+func New(as service.ArticleService) *Server {
+	return &Server{
+		service: as,
+	}
+}
+
+var _ pb.HeadingServiceServer = &Server{}
+
+// SuggestHeadings suggests headings for one article.
+func (s *Server) SuggestHeadings(ctx context.Context, req *pb.SuggestHeadingsRequest,
+) (*pb.SuggestHeadingsResponse, error) {
+	a := req.GetArticle()
+	if err := validateArticle(a); err != nil {
+		return nil, err
+	}
+
+	article, err := s.service.ProcessArticle(ctx, a.GetName(), toPhotoData(a))
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &pb.SuggestHeadingsResponse{
+		Name:     article.Name,
+		Headings: article.Headings,
+		Summary:  toSummary(article.Summary),
+		Scores:   toScoredHeadings(article.Scores),
+	}, nil
+}
+
+// ProcessArticles processes the articles concurrently and streams the additional photo
+// information as it is collected, followed by the headings of each article.
+func (s *Server) ProcessArticles(req *pb.ProcessArticlesRequest, stream pb.HeadingService_ProcessArticlesServer,
+) error {
+	for _, a := range req.GetArticles() {
+		if err := validateArticle(a); err != nil {
+			return err
+		}
+	}
+
+	ctx := stream.Context()
+
+	// stream.Send is not safe to be called concurrently.
+	mu := &sync.Mutex{}
+	var sendErr error
+	send := func(e *pb.ProcessArticlesEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(e)
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, a := range req.GetArticles() {
+		wg.Add(1)
+		go func(a *pb.Article) {
+			defer wg.Done()
+
+			article, err := s.service.ProcessArticleWithEvents(ctx, a.GetName(), toPhotoData(a),
+				func(e service.Event) {
+					send(toEvent(e))
+				})
+
+			he := &pb.HeadingsEvent{
+				Headings: article.Headings,
+				Summary:  toSummary(article.Summary),
+				Scores:   toScoredHeadings(article.Scores),
+			}
+			if err != nil {
+				he.Error = err.Error()
+			}
+			send(&pb.ProcessArticlesEvent{
+				Article: a.GetName(),
+				Event:   &pb.ProcessArticlesEvent_Headings{Headings: he},
+			})
+		}(a)
+	}
+	wg.Wait()
+
+	return sendErr
+}
+
+func validateArticle(a *pb.Article) error {
+	if a == nil {
+		return status.Error(codes.InvalidArgument, "article not provided")
+	}
+	if a.GetName() == "" {
+		return status.Error(codes.InvalidArgument, "article name not provided")
+	}
+	if len(a.GetPhotos()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "no photo records provided for article %s", a.GetName())
+	}
+	return nil
+}
+
+func toStatusError(ctx context.Context, err error) error {
+	if errors.Is(err, service.ErrNoAdditionalInfo) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if service.ClassifyError(ctx, err) == service.Abort {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toPhotoData(a *pb.Article) []photo.Data {
+	photoL := []photo.Data{}
+	for i, p := range a.GetPhotos() {
+		photoL = append(photoL, photo.Data{
+			ArticleID: a.GetName(),
+			ID:        i + 1,
+			Date:      p.GetDate(),
+			LatLon: photo.LatLon{
+				Latitude:  strconv.FormatFloat(p.GetLatitude(), 'f', -1, 64),
+				Longitude: strconv.FormatFloat(p.GetLongitude(), 'f', -1, 64),
+			},
+		})
+	}
+	return photoL
+}
+
+func toEvent(e service.Event) *pb.ProcessArticlesEvent {
+	pe := &pb.ProcessArticlesEvent{
+		Article: e.Article,
+	}
+
+	switch {
+	case e.Location != nil:
+		pe.Event = &pb.ProcessArticlesEvent_Location{Location: &pb.LocationEvent{
+			PhotoId: int32(e.Location.PhotoID),
+			Country: e.Location.Location.Country,
+			City:    e.Location.Location.City,
+		}}
+	case e.Weather != nil:
+		pe.Event = &pb.ProcessArticlesEvent_Weather{Weather: &pb.WeatherEvent{
+			PhotoId: int32(e.Weather.PhotoID),
+			Weather: e.Weather.Weather,
+			Weekday: e.Weather.TimeInfo.Weekday,
+			Month:   e.Weather.TimeInfo.Month,
+			Season:  e.Weather.TimeInfo.Season,
+		}}
+	case e.Poi != nil:
+		places := map[string]int32{}
+		for k, v := range e.Poi.POI {
+			places[k] = int32(v)
+		}
+		pe.Event = &pb.ProcessArticlesEvent_Poi{Poi: &pb.PoiEvent{
+			PhotoId:          int32(e.Poi.PhotoID),
+			PlacesOfInterest: places,
+		}}
+	default:
+		pe.Event = &pb.ProcessArticlesEvent_Error{Error: &pb.ErrorEvent{
+			Message: e.Error.Error(),
+		}}
+	}
+
+	return pe
+}
+
+func toSummary(s service.Summary) *pb.Summary {
+	return &pb.Summary{
+		Country:         s.Country,
+		City:            s.City,
+		Weather:         s.Weather,
+		Weekday:         s.Weekday,
+		Month:           s.Month,
+		Season:          s.Season,
+		PlaceOfInterest: s.PlaceOfInterest,
+		Photos:          int32(s.Photos),
+		Locations:       int32(s.Locations),
+		Weathers:        int32(s.Weathers),
+		Pois:            int32(s.Pois),
+		Errors:          s.Errors,
+	}
+}
+
+func toScoredHeadings(scored []service.ScoredHeading) []*pb.ScoredHeading {
+	scores := make([]*pb.ScoredHeading, 0, len(scored))
+	for _, sh := range scored {
+		scores = append(scores, &pb.ScoredHeading{
+			Heading:     sh.Heading,
+			Score:       sh.Score,
+			Specificity: sh.Specificity,
+			Confidence:  sh.Confidence,
+			Length:      sh.Length,
+			Readability: sh.Readability,
+			Redundancy:  sh.Redundancy,
+		})
+	}
+	return scores
+}
@@ -0,0 +1,238 @@
+// +build service_tests
+
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	"github.com/tamarakaufler/travel-article-headings/internal/grpcapi"
+	pb "github.com/tamarakaufler/travel-article-headings/internal/grpcapi/headingsv1"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+type addrClientM struct{}
+type weathClientM struct{}
+type poiClientM struct{}
+type failingAddrClientM struct{}
+
+var errLocation = errors.New("location not found")
+
+var article1 = &pb.Article{
+	Name: "article1",
+	Photos: []*pb.Photo{
+		{Date: "2019-10-27T13:27:58Z", Latitude: 40.647863, Longitude: 14.366958},
+		{Date: "2019-10-27T13:17:24Z", Latitude: 40.648005, Longitude: 14.367228},
+		{Date: "2019-10-27T13:13:09Z", Latitude: 40.647462, Longitude: 14.369295},
+	},
+}
+
+var article2 = &pb.Article{
+	Name: "article2",
+	Photos: []*pb.Photo{
+		{Date: "2020-03-30 14:12:19", Latitude: 40.528808, Longitude: -73.996106},
+		{Date: "2020-03-30 14:20:10", Latitude: 40.528656, Longitude: -73.998790},
+	},
+}
+
+func TestSuggestHeadings(t *testing.T) {
+	c, cleanup := setup(t, addrClientM{})
+	defer cleanup()
+
+	res, err := c.SuggestHeadings(context.Background(), &pb.SuggestHeadingsRequest{Article: article1})
+	require.NoError(t, err)
+
+	require.Equal(t, "article1", res.GetName())
+	require.Len(t, res.GetHeadings(), 8)
+	require.Len(t, res.GetScores(), 8)
+	for i, sh := range res.GetScores() {
+		require.Equal(t, res.GetHeadings()[i], sh.GetHeading())
+		require.True(t, sh.GetScore() > 0 && sh.GetScore() <= 1, "score %v", sh.GetScore())
+		require.True(t, sh.GetConfidence() > 0, "confidence %v", sh.GetConfidence())
+	}
+	require.Equal(t, "Italy", res.GetSummary().GetCountry())
+	require.Equal(t, "Sorrento", res.GetSummary().GetCity())
+	require.Equal(t, "sunny", res.GetSummary().GetWeather())
+	require.Equal(t, "Autumn", res.GetSummary().GetSeason())
+	require.Equal(t, "Cafes", res.GetSummary().GetPlaceOfInterest())
+	require.EqualValues(t, 3, res.GetSummary().GetPhotos())
+}
+
+func TestSuggestHeadings_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses client.Addresses
+		article   *pb.Article
+		wantCode  codes.Code
+	}{
+		{
+			name:      "article not provided",
+			addresses: addrClientM{},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "no photos",
+			addresses: addrClientM{},
+			article:   &pb.Article{Name: "empty"},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "no location data",
+			addresses: failingAddrClientM{},
+			article:   article1,
+			wantCode:  codes.Unavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, cleanup := setup(t, tt.addresses)
+			defer cleanup()
+
+			_, err := c.SuggestHeadings(context.Background(), &pb.SuggestHeadingsRequest{Article: tt.article})
+			require.Error(t, err)
+			require.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestProcessArticles(t *testing.T) {
+	c, cleanup := setup(t, addrClientM{})
+	defer cleanup()
+
+	stream, err := c.ProcessArticles(context.Background(), &pb.ProcessArticlesRequest{
+		Articles: []*pb.Article{article1, article2},
+	})
+	require.NoError(t, err)
+
+	type counts struct {
+		locations, weathers, pois, headings int
+	}
+	got := map[string]*counts{
+		"article1": {},
+		"article2": {},
+	}
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		cs, ok := got[e.GetArticle()]
+		require.True(t, ok, "unexpected article %s", e.GetArticle())
+		require.Zero(t, cs.headings, "event received after the article headings")
+
+		switch {
+		case e.GetLocation() != nil:
+			cs.locations++
+		case e.GetWeather() != nil:
+			cs.weathers++
+		case e.GetPoi() != nil:
+			cs.pois++
+		case e.GetHeadings() != nil:
+			cs.headings++
+			require.Empty(t, e.GetHeadings().GetError())
+			require.Len(t, e.GetHeadings().GetHeadings(), 8)
+			require.Len(t, e.GetHeadings().GetScores(), 8)
+		default:
+			t.Errorf("unexpected event %v", e)
+		}
+	}
+
+	require.Equal(t, counts{locations: 3, weathers: 3, pois: 3, headings: 1}, *got["article1"])
+	require.Equal(t, counts{locations: 2, weathers: 2, pois: 2, headings: 1}, *got["article2"])
+}
+
+func TestProcessArticles_ErrorEvents(t *testing.T) {
+	c, cleanup := setup(t, failingAddrClientM{})
+	defer cleanup()
+
+	stream, err := c.ProcessArticles(context.Background(), &pb.ProcessArticlesRequest{
+		Articles: []*pb.Article{article2},
+	})
+	require.NoError(t, err)
+
+	errCount := 0
+	var last *pb.ProcessArticlesEvent
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		if e.GetError() != nil {
+			errCount++
+		}
+		last = e
+	}
+
+	require.Equal(t, 2, errCount)
+	require.NotNil(t, last.GetHeadings())
+	require.Empty(t, last.GetHeadings().GetHeadings())
+	require.Equal(t, service.ErrNoAdditionalInfo.Error(), last.GetHeadings().GetError())
+}
+
+func setup(t *testing.T, ac client.Addresses) (pb.HeadingServiceClient, func()) {
+	as := service.ArticleService{
+		Clients: client.Clients{
+			Addresses: ac,
+			Weather:   weathClientM{},
+			POI:       poiClientM{},
+		},
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	gs := grpc.NewServer()
+	pb.RegisterHeadingServiceServer(gs, grpcapi.New(as))
+	go func() {
+		_ = gs.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+
+	return pb.NewHeadingServiceClient(conn), func() {
+		conn.Close()
+		gs.Stop()
+	}
+}
+
+func (ac addrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{
+		Country: "Italy",
+		City:    "Sorrento",
+	}, nil
+}
+
+func (ac failingAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{}, errLocation
+}
+
+func (wc weathClientM) Weather(ctx context.Context, pd photo.Data) (string, error) {
+	return "sunny", nil
+}
+
+func (pc poiClientM) PlacesOfInterest(ctx context.Context, pd photo.Data) (map[string]int, error) {
+	return map[string]int{
+		"Cinemas":     5,
+		"Restaurants": 10,
+		"Cafes":       15,
+	}, nil
+}
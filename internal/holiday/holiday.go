@@ -0,0 +1,254 @@
+// Package holiday provides the public holidays and festivals of a date offline, from calendars
+// of ISO 3166-1 alpha-3 country codes (eg ITA), the codes provided by the HERE reverse geocoding.
+//
+// Holidays are either on a fixed date (eg Christmas on 12-25), relative to the Easter Sunday
+// (eg Easter Monday is 1 day after it) or on the nth weekday of a month (eg Thanksgiving
+// is the 4th Thursday of November). Holidays and festivals can last several days.
+package holiday
+
+import (
+	"bytes"
+	_ "embed" // bundled holiday calendar
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed holidays.json
+var holidays []byte
+
+// Holiday is a public holiday or a festival. Exactly one of Date, Easter or Weekday
+// determines the first day.
+type Holiday struct {
+	Name string `json:"name"`
+	// Date is the fixed month and day of the first day (MM-DD).
+	Date string `json:"date,omitempty"`
+	// Easter is the number of days from the Easter Sunday (negative before it).
+	Easter *int `json:"easter,omitempty"`
+	// Weekday is the Nth weekday of the Month, counted from the end of the month if Nth is negative.
+	Weekday string `json:"weekday,omitempty"`
+	Nth     int    `json:"nth,omitempty"`
+	Month   string `json:"month,omitempty"`
+	// Days the holiday lasts (1 if not provided).
+	Days int `json:"days,omitempty"`
+
+	month   time.Month
+	day     int
+	weekday time.Weekday
+}
+
+// Calendar holds the holidays by country code.
+type Calendar struct {
+	countries map[string][]Holiday
+}
+
+var (
+	defaultCalendar *Calendar
+	defaultOnce     sync.Once
+)
+
+// Default provides the bundled holiday calendar.
+func Default() *Calendar {
+	defaultOnce.Do(func() {
+		c, err := New(bytes.NewReader(holidays))
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundled holiday calendar: %s", err))
+		}
+		defaultCalendar = c
+	})
+	return defaultCalendar
+}
+
+// New reads the holiday calendar in JSON format, eg:
+//
+//	{"ITA": [{"name": "Ferragosto", "date": "08-15"}, {"name": "Easter weekend", "easter": -1, "days": 3}],
+//	 "USA": [{"name": "Thanksgiving", "weekday": "Thursday", "nth": 4, "month": "November"}]}
+func New(r io.Reader) (*Calendar, error) {
+	raw := map[string][]Holiday{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.Wrap(err, "invalid holiday calendar")
+	}
+
+	c := &Calendar{countries: map[string][]Holiday{}}
+	for country, hs := range raw {
+		for i := range hs {
+			if err := hs[i].parse(); err != nil {
+				return nil, errors.Wrapf(err, "invalid holiday calendar: country %s", country)
+			}
+		}
+		c.countries[strings.ToUpper(country)] = hs
+	}
+
+	return c, nil
+}
+
+// Load reads the holiday calendar from the file.
+func Load(path string) (*Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return New(f)
+}
+
+// Extend provides the calendar with the holidays of the other calendar countries replacing
+// the holidays of the same countries.
+func (c *Calendar) Extend(other *Calendar) *Calendar {
+	ext := &Calendar{countries: map[string][]Holiday{}}
+	for _, cal := range []*Calendar{c, other} {
+		if cal == nil {
+			continue
+		}
+		for country, hs := range cal.countries {
+			ext.countries[country] = hs
+		}
+	}
+	return ext
+}
+
+// Holidays provides the names of the holidays of the country on the day of the (local) time,
+// in the calendar order. A nil calendar has no holidays.
+func (c *Calendar) Holidays(t time.Time, country string) []string {
+	if c == nil {
+		return nil
+	}
+
+	day := civil(t)
+	names := []string{}
+	for _, h := range c.countries[strings.ToUpper(country)] {
+		// holidays of the previous year can last into the new year.
+		for _, year := range []int{day.Year() - 1, day.Year()} {
+			first, ok := h.first(year)
+			if ok && !day.Before(first) && day.Before(first.AddDate(0, 0, h.days())) {
+				names = append(names, h.Name)
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	return names
+}
+
+// Easter provides the (Gregorian) Easter Sunday of the year, using the anonymous
+// Gregorian algorithm.
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func (h *Holiday) parse() error {
+	if h.Name == "" {
+		return errors.New("holiday without a name")
+	}
+	if h.Days < 0 {
+		return errors.Errorf("holiday %s: negative number of days", h.Name)
+	}
+
+	rules := 0
+	if h.Date != "" {
+		rules++
+		t, err := time.Parse("01-02", h.Date)
+		if err != nil {
+			return errors.Errorf("holiday %s: invalid date %q: expected MM-DD", h.Name, h.Date)
+		}
+		h.month, h.day = t.Month(), t.Day()
+	}
+	if h.Easter != nil {
+		rules++
+	}
+	if h.Weekday != "" {
+		rules++
+		wd, ok := parseWeekday(h.Weekday)
+		if !ok {
+			return errors.Errorf("holiday %s: unknown weekday %q", h.Name, h.Weekday)
+		}
+		m, ok := parseMonth(h.Month)
+		if !ok {
+			return errors.Errorf("holiday %s: unknown month %q", h.Name, h.Month)
+		}
+		if h.Nth == 0 || h.Nth < -5 || h.Nth > 5 {
+			return errors.Errorf("holiday %s: nth weekday %d out of range", h.Name, h.Nth)
+		}
+		h.weekday, h.month = wd, m
+	}
+	if rules != 1 {
+		return errors.Errorf("holiday %s: expected one of date, easter or weekday", h.Name)
+	}
+
+	return nil
+}
+
+// first provides the first day of the holiday in the year. The holiday does not take place
+// in the years its day is not in its month, eg the 5th Monday of a month with four Mondays
+// or the 29th of February of a common year.
+func (h Holiday) first(year int) (time.Time, bool) {
+	var first time.Time
+	switch {
+	case h.Easter != nil:
+		return Easter(year).AddDate(0, 0, *h.Easter), true
+	case h.Nth > 0:
+		start := time.Date(year, h.month, 1, 0, 0, 0, 0, time.UTC)
+		offset := (int(h.weekday) - int(start.Weekday()) + 7) % 7
+		first = start.AddDate(0, 0, offset+(h.Nth-1)*7)
+	case h.Nth < 0:
+		last := time.Date(year, h.month+1, 0, 0, 0, 0, 0, time.UTC)
+		offset := (int(last.Weekday()) - int(h.weekday) + 7) % 7
+		first = last.AddDate(0, 0, -offset+(h.Nth+1)*7)
+	default:
+		first = time.Date(year, h.month, h.day, 0, 0, 0, 0, time.UTC)
+	}
+	return first, first.Month() == h.month
+}
+
+func (h Holiday) days() int {
+	if h.Days == 0 {
+		return 1
+	}
+	return h.Days
+}
+
+// civil is the day of the time in its own timezone.
+func civil(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if wd.String() == s {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+func parseMonth(s string) (time.Month, bool) {
+	for m := time.January; m <= time.December; m++ {
+		if m.String() == s {
+			return m, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,170 @@
+// +build unit_tests
+
+package holiday_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/holiday"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{year: 2019, want: time.Date(2019, 4, 21, 0, 0, 0, 0, time.UTC)},
+		{year: 2020, want: time.Date(2020, 4, 12, 0, 0, 0, 0, time.UTC)},
+		{year: 2024, want: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{year: 2025, want: time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)},
+		{year: 2038, want: time.Date(2038, 4, 25, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.Format("2006"), func(t *testing.T) {
+			require.Equal(t, tt.want, holiday.Easter(tt.year))
+		})
+	}
+}
+
+func TestCalendar_Holidays(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 15, 0, 0, 0, time.UTC)
+	}
+	custom, err := holiday.New(strings.NewReader(
+		`{"aut": [{"name": "Silvester run", "date": "12-30", "days": 4},
+			{"name": "Fifth Monday fair", "weekday": "Monday", "nth": 5, "month": "March"},
+			{"name": "Leap day party", "date": "02-29"}]}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		calendar *holiday.Calendar
+		t        time.Time
+		country  string
+		want     []string
+	}{
+		{name: "Christmas markets in Vienna", calendar: holiday.Default(), t: date(2019, time.December, 10),
+			country: "AUT", want: []string{"Christmas markets"}},
+		{name: "Christmas Eve in Vienna", calendar: holiday.Default(), t: date(2019, time.December, 24),
+			country: "AUT", want: []string{"Christmas markets", "Christmas"}},
+		{name: "Easter Saturday in Sorrento", calendar: holiday.Default(), t: date(2019, time.April, 20),
+			country: "ITA", want: []string{"Easter weekend"}},
+		{name: "Easter Monday in Sorrento", calendar: holiday.Default(), t: date(2019, time.April, 22),
+			country: "ITA", want: []string{"Easter weekend"}},
+		{name: "Tuesday after Easter in Sorrento", calendar: holiday.Default(), t: date(2019, time.April, 23),
+			country: "ITA"},
+		{name: "Good Friday in London", calendar: holiday.Default(), t: date(2020, time.April, 10),
+			country: "GBR", want: []string{"Easter weekend"}},
+		{name: "Thanksgiving", calendar: holiday.Default(), t: date(2020, time.November, 26),
+			country: "USA", want: []string{"Thanksgiving"}},
+		{name: "Thursday before Thanksgiving", calendar: holiday.Default(), t: date(2020, time.November, 19),
+			country: "USA"},
+		{name: "last Monday of May", calendar: holiday.Default(), t: date(2021, time.May, 31),
+			country: "GBR", want: []string{"Spring bank holiday"}},
+		{name: "first Monday of May", calendar: holiday.Default(), t: date(2021, time.May, 3),
+			country: "GBR", want: []string{"Early May bank holiday"}},
+		{name: "country code case", calendar: holiday.Default(), t: date(2019, time.August, 15),
+			country: "ita", want: []string{"Ferragosto"}},
+		{name: "unknown country", calendar: holiday.Default(), t: date(2019, time.December, 25),
+			country: "XXX"},
+		{name: "no country", calendar: holiday.Default(), t: date(2019, time.December, 25)},
+		{name: "nil calendar", t: date(2019, time.December, 25), country: "ITA"},
+		{name: "holiday lasting into the new year", calendar: custom, t: date(2020, time.January, 2),
+			country: "AUT", want: []string{"Silvester run"}},
+		{name: "5th Monday", calendar: custom, t: date(2021, time.March, 29),
+			country: "AUT", want: []string{"Fifth Monday fair"}},
+		{name: "no 5th Monday in the month", calendar: custom, t: date(2022, time.April, 4),
+			country: "AUT"},
+		{name: "leap day", calendar: custom, t: date(2020, time.February, 29),
+			country: "AUT", want: []string{"Leap day party"}},
+		{name: "no leap day in a common year", calendar: custom, t: date(2019, time.March, 1),
+			country: "AUT"},
+		{name: "extended calendar replaces the country", calendar: holiday.Default().Extend(custom),
+			t: date(2019, time.December, 24), country: "AUT"},
+		{name: "extended calendar keeps other countries", calendar: holiday.Default().Extend(custom),
+			t: date(2019, time.December, 24), country: "CZE", want: []string{"Christmas markets", "Christmas"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.calendar.Holidays(tt.t, tt.country))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `{"USA": [{"name": "Independence Day", "date": "07-04"},
+				{"name": "Easter", "easter": 0},
+				{"name": "Memorial Day", "weekday": "Monday", "nth": -1, "month": "May"}]}`,
+		},
+		{
+			name:    "no name",
+			data:    `{"USA": [{"date": "07-04"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			data:    `{"USA": [{"name": "Independence Day", "date": "07/04"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no rule",
+			data:    `{"USA": [{"name": "Independence Day"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "several rules",
+			data:    `{"USA": [{"name": "Independence Day", "date": "07-04", "easter": 0}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown weekday",
+			data:    `{"USA": [{"name": "Memorial Day", "weekday": "Mon", "nth": -1, "month": "May"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "nth out of range",
+			data:    `{"USA": [{"name": "Memorial Day", "weekday": "Monday", "nth": 0, "month": "May"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "nth above range",
+			data:    `{"USA": [{"name": "Memorial Day", "weekday": "Monday", "nth": 6, "month": "May"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "nth below range",
+			data:    `{"USA": [{"name": "Memorial Day", "weekday": "Monday", "nth": -6, "month": "May"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative days",
+			data:    `{"USA": [{"name": "Independence Day", "date": "07-04", "days": -1}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			data:    `{"USA": [`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := holiday.New(strings.NewReader(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
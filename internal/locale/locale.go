@@ -0,0 +1,285 @@
+// Package locale provides the message catalogs of the languages the headings are published in.
+//
+// A catalog holds the heading templates and vocabulary of the language, the translations
+// of the article information (month, weekday and season names, weather, places of interest etc)
+// and the grammar hooks used by the templates: the noun genders, the definite articles
+// and the "in" and "near" prepositions agreeing with them, the list conjunction, the adjective
+// inflection and the elisions and contractions applied to the finished headings
+// (eg Italian la estate -> l'estate).
+package locale
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed catalogs/*.json
+var catalogs embed.FS
+
+// English is the default locale. Its catalog uses the default heading templates.
+const English = "en"
+
+// Catalog of a language.
+type Catalog struct {
+	// Locale is the catalog name, eg de.
+	Locale string `json:"-"`
+	// Lang is the BCP 47 language tag of the place names, eg de-DE.
+	Lang string `json:"lang"`
+	// Templates replace the default heading templates, if provided.
+	Templates []string `json:"templates"`
+	// Messages translate the English article information, eg "sunny": "sonnig".
+	Messages map[string]string `json:"messages"`
+	// Numbers spell out small numbers, starting with zero.
+	Numbers    []string   `json:"numbers"`
+	Vocabulary Vocabulary `json:"vocabulary"`
+	Grammar    Grammar    `json:"grammar"`
+}
+
+// Vocabulary of the heading templates.
+type Vocabulary struct {
+	Starts      []string `json:"starts"`
+	HappyStarts []string `json:"happyStarts"`
+	Company     []string `json:"company"`
+	ForPlaces   []string `json:"forPlaces"`
+	Adjectives  []string `json:"adjectives"`
+}
+
+// Grammar hooks of the language. Genders are arbitrary keys (eg m, f, n, mp), the "*" key
+// matches any gender.
+type Grammar struct {
+	// Genders of the (translated) nouns. Nouns not listed have the gender of their first word listed
+	// in Heads (eg Piazza for Piazza Navona, Dom for Kölner Dom) or the DefaultGender.
+	Genders       map[string]string `json:"genders"`
+	Heads         map[string]string `json:"heads"`
+	DefaultGender string            `json:"defaultGender"`
+	// Articles are the definite articles by gender.
+	Articles map[string]string `json:"articles"`
+	// In is the "in" preposition (contracted with the definite article) by gender.
+	In map[string]string `json:"in"`
+	// Near is the "near" preposition (contracted with the definite article) by gender, eg Italian vicino al.
+	Near map[string]string `json:"near"`
+	// And is the conjunction of the last listed item, eg und.
+	And string `json:"and"`
+	// Inflections of adjectives by form (eg dative) and gender: the first of the [suffix, replacement]
+	// pairs with a matching suffix replaces the suffix of the last word of the adjective.
+	// The "" form is used for forms not listed.
+	Inflections map[string]map[string][][2]string `json:"inflections"`
+	// Elisions replace words followed by a vowel, eg "la": "l'".
+	Elisions map[string]string `json:"elisions"`
+	// Contractions replace phrases, eg "de el": "del".
+	Contractions map[string]string `json:"contractions"`
+}
+
+var (
+	bundled   = map[string]*Catalog{}
+	bundledMu sync.Mutex
+)
+
+// Locales lists the bundled locales.
+func Locales() []string {
+	entries, err := catalogs.ReadDir("catalogs")
+	if err != nil {
+		return nil
+	}
+
+	locales := []string{}
+	for _, e := range entries {
+		locales = append(locales, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Load provides the bundled catalog of the locale. An empty locale means English.
+func Load(locale string) (*Catalog, error) {
+	if locale == "" {
+		locale = English
+	}
+
+	bundledMu.Lock()
+	defer bundledMu.Unlock()
+
+	if c, ok := bundled[locale]; ok {
+		return c, nil
+	}
+
+	f, err := catalogs.Open(path.Join("catalogs", locale+".json"))
+	if err != nil {
+		return nil, errors.Errorf("unknown locale %q: expected one of %s", locale, strings.Join(Locales(), ", "))
+	}
+	defer f.Close()
+
+	c, err := Read(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "locale %s", locale)
+	}
+	c.Locale = locale
+	bundled[locale] = c
+
+	return c, nil
+}
+
+// Default provides the English catalog.
+func Default() *Catalog {
+	c, err := Load(English)
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled English catalog: %s", err))
+	}
+	return c
+}
+
+// Read reads the catalog in JSON format.
+func Read(r io.Reader) (*Catalog, error) {
+	c := &Catalog{}
+	if err := json.NewDecoder(r).Decode(c); err != nil {
+		return nil, errors.Wrap(err, "invalid catalog")
+	}
+	if c.Lang == "" {
+		return nil, errors.New("invalid catalog: missing lang")
+	}
+	return c, nil
+}
+
+// T translates the message. Messages without a translation are kept.
+func (c *Catalog) T(msg string) string {
+	if t, ok := c.Messages[msg]; ok && t != "" {
+		return t
+	}
+	return msg
+}
+
+// Number spells out a small number.
+func (c *Catalog) Number(n int) string {
+	if n >= 0 && n < len(c.Numbers) {
+		return c.Numbers[n]
+	}
+	return strconv.Itoa(n)
+}
+
+// Gender provides the gender of the noun.
+func (c *Catalog) Gender(noun string) string {
+	if g, ok := c.Grammar.Genders[noun]; ok {
+		return g
+	}
+	for _, w := range strings.Fields(noun) {
+		if g, ok := c.Grammar.Heads[w]; ok {
+			return g
+		}
+	}
+	return c.Grammar.DefaultGender
+}
+
+// Article provides the definite article agreeing with the noun.
+func (c *Catalog) Article(noun string) string {
+	return byGender(c.Grammar.Articles, c.Gender(noun))
+}
+
+// In provides the "in" preposition agreeing with the noun, eg German im for Italien.
+func (c *Catalog) In(noun string) string {
+	return byGender(c.Grammar.In, c.Gender(noun))
+}
+
+// Near provides the "near" preposition agreeing with the noun, eg Italian vicino al for Colosseo,
+// vicino a for Piazza Navona.
+func (c *Catalog) Near(noun string) string {
+	return byGender(c.Grammar.Near, c.Gender(noun))
+}
+
+// List lists the items, leaving out the empty ones, eg musei, teatri e giardini botanici.
+func (c *Catalog) List(items []string) string {
+	listed := []string{}
+	for _, item := range items {
+		if item != "" {
+			listed = append(listed, item)
+		}
+	}
+	if len(listed) < 2 {
+		return strings.Join(listed, "")
+	}
+
+	last := len(listed) - 1
+	return strings.Join(listed[:last], ", ") + " " + c.Grammar.And + " " + listed[last]
+}
+
+// Inflect inflects the last word of the adjective in the form to agree with the noun.
+func (c *Catalog) Inflect(form, adjective, noun string) string {
+	rules, ok := c.Grammar.Inflections[form]
+	if !ok {
+		rules = c.Grammar.Inflections[""]
+	}
+	suffixes, ok := rules[c.Gender(noun)]
+	if !ok {
+		suffixes = rules["*"]
+	}
+
+	i := strings.LastIndex(adjective, " ") + 1
+	head, last := adjective[:i], adjective[i:]
+	if last == "" {
+		return adjective
+	}
+	for _, s := range suffixes {
+		if strings.HasSuffix(last, s[0]) {
+			return head + strings.TrimSuffix(last, s[0]) + s[1]
+		}
+	}
+	return adjective
+}
+
+// Polish applies the contractions and elisions (keeping the capitalization) to the heading
+// and removes the superfluous spaces left by empty information.
+func (c *Catalog) Polish(heading string) string {
+	words := strings.Fields(heading)
+	s := " " + strings.Join(words, " ") + " "
+
+	for phrase, contracted := range c.Grammar.Contractions {
+		s = strings.ReplaceAll(s, " "+phrase+" ", " "+contracted+" ")
+	}
+
+	words = strings.Fields(s)
+	polished := []string{}
+	for i := 0; i < len(words); i++ {
+		elided, ok := c.Grammar.Elisions[strings.ToLower(words[i])]
+		if ok && i+1 < len(words) && startsWithVowel(words[i+1]) {
+			if words[i] != strings.ToLower(words[i]) {
+				elided = Capitalize(elided)
+			}
+			words[i+1] = elided + words[i+1]
+			continue
+		}
+		polished = append(polished, words[i])
+	}
+
+	return strings.Join(polished, " ")
+}
+
+// Capitalize upper-cases the first letter.
+func Capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func byGender(forms map[string]string, gender string) string {
+	if f, ok := forms[gender]; ok {
+		return f
+	}
+	return forms["*"]
+}
+
+func startsWithVowel(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return strings.ContainsRune("aeiouàèéìòùAEIOUÀÈÉÌÒÙ", r)
+}
@@ -0,0 +1,174 @@
+// +build unit_tests
+
+package locale_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
+)
+
+func TestLoad(t *testing.T) {
+	require.Equal(t, []string{"de", "en", "es", "it"}, locale.Locales())
+
+	for _, l := range locale.Locales() {
+		c, err := locale.Load(l)
+		require.NoError(t, err)
+		require.Equal(t, l, c.Locale)
+		require.NotEmpty(t, c.Lang)
+	}
+
+	c, err := locale.Load("")
+	require.NoError(t, err)
+	require.Equal(t, "en-US", c.Lang)
+	require.Empty(t, c.Templates)
+
+	_, err = locale.Load("fr")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown locale")
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog string
+		wantErr bool
+	}{
+		{
+			name:    "valid catalog",
+			catalog: `{"lang": "fr-FR", "messages": {"sunny": "ensoleillé"}}`,
+		},
+		{
+			name:    "missing lang",
+			catalog: `{"messages": {"sunny": "ensoleillé"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			catalog: `{"lang": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := locale.Read(strings.NewReader(tt.catalog))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "ensoleillé", c.T("sunny"))
+			require.Equal(t, "rainy", c.T("rainy"))
+		})
+	}
+}
+
+func TestCatalog_Grammar(t *testing.T) {
+	de, err := locale.Load("de")
+	require.NoError(t, err)
+	it, err := locale.Load("it")
+	require.NoError(t, err)
+	es, err := locale.Load("es")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "German in with neuter country",
+			got:  de.In("Italien"),
+			want: "im",
+		},
+		{
+			name: "German dative adjective",
+			got:  de.Inflect("dative", "sonnig", "Italien"),
+			want: "sonnigen",
+		},
+		{
+			name: "German inflects the last word only",
+			got:  de.Inflect("dative", "überwiegend regnerisch", "Italien"),
+			want: "überwiegend regnerischen",
+		},
+		{
+			name: "Italian feminine adjective",
+			got:  it.Inflect("", "soleggiato", "Italia"),
+			want: "soleggiata",
+		},
+		{
+			name: "number spelled out",
+			got:  it.Number(4),
+			want: "quattro",
+		},
+		{
+			name: "number out of range",
+			got:  it.Number(42),
+			want: "42",
+		},
+		{
+			name: "German near by the last word",
+			got:  de.Near("Kölner Dom"),
+			want: "am",
+		},
+		{
+			name: "German near by the first word",
+			got:  de.Near("Piazza Navona"),
+			want: "an der",
+		},
+		{
+			name: "Italian near a square without article",
+			got:  it.Near("Piazza Navona"),
+			want: "vicino a",
+		},
+		{
+			name: "Italian near elided",
+			got:  it.Polish(it.Near("Arco di Tito") + " Arco di Tito"),
+			want: "vicino all'Arco di Tito",
+		},
+		{
+			name: "Spanish near contracted",
+			got:  es.Polish(es.Near("Coliseo") + " Coliseo"),
+			want: "junto al Coliseo",
+		},
+		{
+			name: "German list",
+			got:  de.List([]string{"Theatern", "", "Museen", "Schwimmbädern"}),
+			want: "Theatern, Museen und Schwimmbädern",
+		},
+		{
+			name: "Spanish list of two",
+			got:  es.List([]string{"museos", "jardines botánicos"}),
+			want: "museos y jardines botánicos",
+		},
+		{
+			name: "Italian list of one",
+			got:  it.List([]string{"", "musei"}),
+			want: "musei",
+		},
+		{
+			name: "Italian elision",
+			got:  it.Polish("La  esperienza di una vita a Sorrento "),
+			want: "L'esperienza di una vita a Sorrento",
+		},
+		{
+			name: "Spanish contraction",
+			got:  es.Polish("Una escapada de el verano"),
+			want: "Una escapada del verano",
+		},
+		{
+			name: "capitalized",
+			got:  locale.Capitalize("über Italien"),
+			want: "Über Italien",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.got)
+		})
+	}
+}
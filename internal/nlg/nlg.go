@@ -0,0 +1,260 @@
+// Package nlg realises the English heading phrases grammatically: the definite article
+// of the country and landmark names (the USA, the Colosseum), the prepositions by the kind
+// of place (on Capri, on the Amalfi Coast), the position of the weather adjective (in the sunny
+// Netherlands) and the plural lower case places of interest (bars, swimming pools).
+package nlg
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// articled are the places named with the definite article.
+var articled = set(
+	"USA", "US", "UK", "UAE", "DRC",
+	"Netherlands", "Philippines", "Bahamas", "Maldives", "Seychelles", "Comoros", "Gambia",
+	"Vatican", "Vatican City", "Ivory Coast", "Balkans", "Caribbean", "Riviera", "Algarve",
+	"Peloponnese", "Lake District", "Cotswolds", "Midwest", "Hamptons", "Alps", "Dolomites", "Pyrenees", "Andes",
+	"Highlands",
+)
+
+// articledPrefixes and articledSuffixes are the name parts of the places named with the definite
+// article, eg the United Kingdom, the Czech Republic, the Canary Islands.
+var (
+	articledPrefixes = []string{"United ", "Republic of ", "Isle of ", "Gulf of ", "Bay of "}
+	articledSuffixes = []string{
+		" Republic", " Kingdom", " Islands", " Isles", " Emirates", " States", " Federation",
+		" Coast", " Riviera", " Alps", " Mountains", " Highlands", " Dolomites", " Valley", " Peninsula",
+		" District",
+	}
+)
+
+// articledLandmarks are the landmarks named with the definite article, eg the Colosseum.
+var articledLandmarks = set(
+	"Colosseum", "Pantheon", "Parthenon", "Acropolis", "Alhambra", "Louvre", "Prado", "Uffizi", "Kremlin",
+	"Duomo", "Rialto", "Forum", "Vatican", "Sagrada Familia", "Atomium", "Sphinx", "Shard", "Gherkin",
+)
+
+// landmarkSuffixes are the name parts of the landmarks named with the definite article, eg the Trevi
+// Fountain, the Eiffel Tower, but Tower Bridge, St Peter's Basilica.
+var landmarkSuffixes = []string{
+	" Fountain", " Tower", " Gate", " Steps", " Arch", " Wall", " Museum", " Museums", " Gallery",
+	" Opera House", " Theatre", " Lighthouse", " Aqueduct", " Canal",
+}
+
+// islands are named without the article and take the preposition on (on Capri, but in Sicily).
+var islands = set(
+	"Capri", "Ischia", "Procida", "Elba", "Mykonos", "Santorini", "Corfu", "Crete", "Rhodes", "Kos",
+	"Naxos", "Paros", "Hvar", "Brač", "Korčula", "Bali", "Lombok", "Mallorca", "Majorca", "Ibiza",
+	"Menorca", "Minorca", "Tenerife", "Lanzarote", "Madeira", "Skye", "Mull", "Iona", "Anglesey",
+	"Sylt", "Rügen", "Gozo", "Murano", "Burano", "Lido", "Nantucket", "Maui", "Oahu", "Kauai",
+)
+
+// onSuffixes are the name parts of the places taking the preposition on, eg on the Amalfi Coast,
+// but in the Ivory Coast (country).
+var (
+	onSuffixes = []string{" Island", " Coast", " Riviera", " Peninsula", " Isle"}
+	onPlaces   = set("Riviera")
+	inPlaces   = set("Ivory Coast")
+)
+
+// Article provides the place name with the definite article, if it is named with it,
+// eg the USA, the Czech Republic, but Italy and The Hague.
+func Article(place string) string {
+	if needsArticle(place) {
+		return "the " + place
+	}
+	return place
+}
+
+// Locative provides the phrase locating something in the place, with the preposition by the kind
+// of place and with the optional adjective placed after the article, eg in the sunny USA,
+// on rainy Capri, on the Amalfi Coast, on Lake Como, in Sorrento.
+func Locative(adjective, place string) string {
+	if place == "" {
+		return ""
+	}
+
+	prep := "in"
+	switch {
+	case inPlaces[place]:
+	case islands[place] || onPlaces[place] || hasSuffix(place, onSuffixes):
+		prep = "on"
+	case strings.HasPrefix(place, "Lake ") && !articled[place], strings.HasPrefix(place, "Isle of "),
+		strings.HasPrefix(place, "Mount "):
+		prep = "on"
+	}
+
+	words := []string{prep}
+	if needsArticle(place) {
+		words = append(words, "the")
+	}
+	if adjective != "" {
+		words = append(words, adjective)
+	}
+	return strings.Join(append(words, place), " ")
+}
+
+// Landmark provides the landmark name with the definite article, if it is named with it, eg the Colosseum,
+// the Trevi Fountain, the Tower of London, but Piazza Navona, St Peter's Basilica and Central Park.
+func Landmark(name string) string {
+	switch {
+	case name == "" || strings.HasPrefix(name, "The ") || strings.Contains(name, "'s "):
+		return name
+	case articledLandmarks[name] || strings.Contains(name, " of ") || hasSuffix(name, landmarkSuffixes):
+		return "the " + name
+	default:
+		return name
+	}
+}
+
+// Places provides the places of interest category as a plural common noun, eg bars,
+// swimming pools, art galleries. Acronyms keep their casing, eg UNESCO sites.
+func Places(category string) string {
+	words := strings.Fields(category)
+	if len(words) == 0 {
+		return ""
+	}
+	for i, w := range words {
+		if !isAcronym(w) {
+			words[i] = strings.ToLower(w)
+		}
+	}
+	last := len(words) - 1
+	if !isPlural(words[last]) {
+		words[last] = Plural(words[last])
+	}
+	return strings.Join(words, " ")
+}
+
+// PlaceList provides the places of interest categories as plural common nouns listed
+// with commas and and, eg museums, bars and botanical gardens.
+func PlaceList(categories []string) string {
+	places := []string{}
+	for _, c := range categories {
+		if p := Places(c); p != "" {
+			places = append(places, p)
+		}
+	}
+	return List(places)
+}
+
+// List provides the items listed with commas and and, eg museums, bars and parks.
+func List(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+	}
+}
+
+// Plural provides the plural of the English noun.
+func Plural(noun string) string {
+	lower := strings.ToLower(noun)
+	if p, ok := irregular[lower]; ok {
+		return matchCase(noun, p)
+	}
+
+	switch {
+	case hasSuffix(lower, []string{"s", "x", "z", "ch", "sh"}):
+		return noun + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !isVowel(rune(lower[len(lower)-2])):
+		return noun[:len(noun)-1] + "ies"
+	default:
+		return noun + "s"
+	}
+}
+
+// Count provides the noun in singular or plural agreeing with the count.
+func Count(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return Plural(noun)
+}
+
+// Common provides the capitalized word as a common noun (lower case), eg weekend, summer,
+// wet season. Weekday and month names are proper nouns and keep their capitalization.
+func Common(word string) string {
+	if proper[word] {
+		return word
+	}
+	return strings.ToLower(word)
+}
+
+var proper = set(
+	"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+	"January", "February", "March", "April", "May", "June", "July", "August", "September", "October",
+	"November", "December",
+)
+
+var irregular = map[string]string{
+	"person": "people", "child": "children", "man": "men", "woman": "women",
+	"leaf": "leaves", "shelf": "shelves", "wolf": "wolves",
+	"volcano": "volcanoes", "hero": "heroes", "potato": "potatoes", "tomato": "tomatoes",
+}
+
+// singularS are nouns ending with s in singular.
+var singularS = set("bus", "gas", "campus", "circus", "status", "canvas", "oasis", "chess", "glass", "pass",
+	"class")
+
+func isPlural(word string) bool {
+	lower := strings.ToLower(word)
+	for _, p := range irregular {
+		if lower == p {
+			return true
+		}
+	}
+	return strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") && !singularS[lower]
+}
+
+func needsArticle(place string) bool {
+	if articled[place] {
+		return true
+	}
+	for _, p := range articledPrefixes {
+		if strings.HasPrefix(place, p) {
+			return true
+		}
+	}
+	return hasSuffix(place, articledSuffixes)
+}
+
+func hasSuffix(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiou", r)
+}
+
+func isAcronym(w string) bool {
+	return utf8.RuneCountInString(w) > 1 && strings.ToUpper(w) == w && strings.ToLower(w) != w
+}
+
+// matchCase capitalizes the plural like the noun.
+func matchCase(noun, plural string) string {
+	r, _ := utf8.DecodeRuneInString(noun)
+	if unicode.IsUpper(r) {
+		p, size := utf8.DecodeRuneInString(plural)
+		return string(unicode.ToUpper(p)) + plural[size:]
+	}
+	return plural
+}
+
+func set(words ...string) map[string]bool {
+	m := map[string]bool{}
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
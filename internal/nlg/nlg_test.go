@@ -0,0 +1,201 @@
+// +build unit_tests
+
+package nlg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/nlg"
+)
+
+func TestLocative(t *testing.T) {
+	tests := []struct {
+		adjective string
+		place     string
+		want      string
+	}{
+		{"", "Italy", "in Italy"},
+		{"sunny", "Italy", "in sunny Italy"},
+		{"boiling hot", "USA", "in the boiling hot USA"},
+		{"", "United States", "in the United States"},
+		{"", "United Kingdom", "in the United Kingdom"},
+		{"rainy", "Netherlands", "in the rainy Netherlands"},
+		{"", "Philippines", "in the Philippines"},
+		{"", "Czech Republic", "in the Czech Republic"},
+		{"", "Dominican Republic", "in the Dominican Republic"},
+		{"", "United Arab Emirates", "in the United Arab Emirates"},
+		{"", "Ivory Coast", "in the Ivory Coast"},
+		{"", "Gambia", "in the Gambia"},
+		{"", "Vatican City", "in the Vatican City"},
+		{"", "Canary Islands", "in the Canary Islands"},
+		{"", "Faroe Islands", "in the Faroe Islands"},
+		{"", "Isle of Skye", "on the Isle of Skye"},
+		{"", "Amalfi Coast", "on the Amalfi Coast"},
+		{"sunny", "French Riviera", "on the sunny French Riviera"},
+		{"", "Riviera", "on the Riviera"},
+		{"", "Dolomites", "in the Dolomites"},
+		{"", "Swiss Alps", "in the Swiss Alps"},
+		{"", "Lake District", "in the Lake District"},
+		{"misty", "Lake Como", "on misty Lake Como"},
+		{"", "Mount Etna", "on Mount Etna"},
+		{"", "Capri", "on Capri"},
+		{"windy", "Mykonos", "on windy Mykonos"},
+		{"", "Sicily", "in Sicily"},
+		{"", "Long Island", "on Long Island"},
+		{"", "The Hague", "in The Hague"},
+		{"", "Sorrento", "in Sorrento"},
+		{"", "New York", "in New York"},
+		{"", "Los Angeles", "in Los Angeles"},
+		{"", "Rio de Janeiro", "in Rio de Janeiro"},
+		{"", "St. Moritz", "in St. Moritz"},
+		{"", "Côte d'Ivoire", "in Côte d'Ivoire"},
+		{"", "Bosnia and Herzegovina", "in Bosnia and Herzegovina"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.Locative(tt.adjective, tt.place))
+		})
+	}
+}
+
+func TestArticle(t *testing.T) {
+	tests := []struct {
+		place string
+		want  string
+	}{
+		{place: "USA", want: "the USA"},
+		{place: "UK", want: "the UK"},
+		{place: "Netherlands", want: "the Netherlands"},
+		{place: "Bahamas", want: "the Bahamas"},
+		{place: "Maldives", want: "the Maldives"},
+		{place: "Campania", want: "Campania"},
+		{place: "Italy", want: "Italy"},
+		{place: "Barbados", want: "Barbados"},
+		{place: "Cyprus", want: "Cyprus"},
+		{place: "The Hague", want: "The Hague"},
+		{place: "Napa Valley", want: "the Napa Valley"},
+		{place: "Balearic Isles", want: "the Balearic Isles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.place, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.Article(tt.place))
+		})
+	}
+}
+
+func TestLandmark(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Colosseum", want: "the Colosseum"},
+		{name: "Trevi Fountain", want: "the Trevi Fountain"},
+		{name: "Eiffel Tower", want: "the Eiffel Tower"},
+		{name: "Spanish Steps", want: "the Spanish Steps"},
+		{name: "Tower of London", want: "the Tower of London"},
+		{name: "British Museum", want: "the British Museum"},
+		{name: "Piazza Navona", want: "Piazza Navona"},
+		{name: "Tower Bridge", want: "Tower Bridge"},
+		{name: "St Peter's Basilica", want: "St Peter's Basilica"},
+		{name: "Central Park", want: "Central Park"},
+		{name: "The Little Mermaid", want: "The Little Mermaid"},
+		{name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.Landmark(tt.name))
+		})
+	}
+}
+
+func TestPlaces(t *testing.T) {
+	tests := []struct {
+		category string
+		want     string
+	}{
+		{category: "Restaurants", want: "restaurants"},
+		{category: "Swimming Pools", want: "swimming pools"},
+		{category: "Shopping Centres", want: "shopping centres"},
+		{category: "Botanical Gardens", want: "botanical gardens"},
+		{category: "Museum", want: "museums"},
+		{category: "Cafe", want: "cafes"},
+		{category: "Beach", want: "beaches"},
+		{category: "Church", want: "churches"},
+		{category: "Art Gallery", want: "art galleries"},
+		{category: "Casino", want: "casinos"},
+		{category: "Bus Stop", want: "bus stops"},
+		{category: "Volcano", want: "volcanoes"},
+		{category: "Glass", want: "glasses"},
+		{category: "UNESCO Site", want: "UNESCO sites"},
+		{category: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.Places(tt.category))
+		})
+	}
+}
+
+func TestPlaceList(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		want       string
+	}{
+		{name: "none"},
+		{name: "one", categories: []string{"Museums"}, want: "museums"},
+		{name: "two", categories: []string{"Museums", "Botanical Gardens"}, want: "museums and botanical gardens"},
+		{name: "several with an empty one", categories: []string{"Bars", "", "Pub", "UNESCO Site"},
+			want: "bars, pubs and UNESCO sites"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.PlaceList(tt.categories))
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		n    int
+		noun string
+		want string
+	}{
+		{n: 1, noun: "city", want: "city"},
+		{n: 4, noun: "city", want: "cities"},
+		{n: 2, noun: "day", want: "days"},
+		{n: 0, noun: "Person", want: "People"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.Count(tt.n, tt.noun))
+		})
+	}
+}
+
+func TestCommon(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "Weekend", want: "weekend"},
+		{word: "Summer", want: "summer"},
+		{word: "Wet season", want: "wet season"},
+		{word: "Sunday", want: "Sunday"},
+		{word: "August", want: "August"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			require.Equal(t, tt.want, nlg.Common(tt.word))
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package photo
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
 
 type (
 	// PhotoData ...
@@ -13,7 +16,10 @@ type (
 
 	Location struct {
 		Country string
-		City    string
+		// CountryCode is the ISO 3166-1 alpha-3 country code, eg ITA.
+		CountryCode string
+		State       string
+		City        string
 	}
 
 	// LatLon ...
@@ -21,13 +27,27 @@ type (
 		Latitude  string
 		Longitude string
 	}
+
+	// Landmark is a named place of interest near the photo, eg Colosseum.
+	Landmark struct {
+		Name string
+		// Category is the kind of place of interest, eg Museums, if known.
+		Category string
+		// Distance from the photo in meters.
+		Distance float64
+		// Popularity of the landmark, eg the number of its reviews.
+		Popularity int
+	}
 )
 
 // TimeInfo ...
 type TimeInfo struct {
-	Weekday string
-	Month   string
-	Season  string
+	Weekday   string
+	Month     string
+	Season    string
+	TimeOfDay string
+	// Holidays lists the public holidays and festivals of the photo country on the photo day.
+	Holidays []string
 }
 
 // AdditionalInfo ...
@@ -62,6 +82,8 @@ type (
 		ArticleID string
 		PhotoID   int
 		POI       map[string]int
+		// Landmarks are the named places near the photo, if the places of interest provider names them.
+		Landmarks []Landmark
 	}
 
 	Channels map[string]Channel
@@ -72,7 +94,7 @@ type (
 		Poi      chan PoiM
 
 		Cancel chan struct{}
-		Error  chan string
+		Error  chan error
 	}
 )
 
@@ -88,3 +110,14 @@ type (
 		Heading *sync.WaitGroup
 	}
 )
+
+// ParseDate parses the photo date in either of the supported formats:
+// RFC3339 or "2006-01-02 15:04:05" (UTC).
+func ParseDate(d string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, d)
+	if err != nil {
+		layout := "2006-01-02 15:04:05"
+		return time.Parse(layout, d)
+	}
+	return t, nil
+}
@@ -0,0 +1,140 @@
+// Package poi scores the places of interest categories near the article photos by how distinctive
+// they are for the trip, compared with the baseline shares of the categories: globally or in the country
+// of ISO 3166-1 alpha-3 code (eg ITA), the codes provided by the HERE reverse geocoding.
+//
+// The distinctiveness is TF-IDF style: the share of the category near the photos weighted by the log
+// of its lift over the baseline share. Everyday categories, eg restaurants, are only distinctive if there
+// are many more of them than usual, while a few botanical gardens already are.
+package poi
+
+import (
+	"bytes"
+	_ "embed" // bundled baseline
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed baseline.json
+var baseline []byte
+
+// global are the baseline shares of all countries.
+const global = "global"
+
+// MinShare is the baseline share of the categories not in the baseline.
+const MinShare = 0.005
+
+// Baseline holds the usual shares (0-1) of the places of interest categories among all places,
+// globally and by country code.
+type Baseline struct {
+	global    map[string]float64
+	countries map[string]map[string]float64
+}
+
+var (
+	defaultBaseline *Baseline
+	defaultOnce     sync.Once
+)
+
+// Default provides the bundled baseline.
+func Default() *Baseline {
+	defaultOnce.Do(func() {
+		b, err := New(bytes.NewReader(baseline))
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundled places of interest baseline: %s", err))
+		}
+		defaultBaseline = b
+	})
+	return defaultBaseline
+}
+
+// New reads the baseline in JSON format: the global shares and the shares by country code. The categories
+// missing in a country are shared as globally, eg:
+//
+//	{"global": {"Restaurants": 0.3, "Pubs": 0.06, "Botanical Gardens": 0.005},
+//	 "GBR": {"Pubs": 0.18}}
+func New(r io.Reader) (*Baseline, error) {
+	raw := map[string]map[string]float64{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.Wrap(err, "invalid places of interest baseline")
+	}
+
+	b := &Baseline{global: map[string]float64{}, countries: map[string]map[string]float64{}}
+	for country, shares := range raw {
+		for category, share := range shares {
+			if share <= 0 || share > 1 {
+				return nil, errors.Errorf("invalid places of interest baseline: %s share of %s %v is not within (0, 1]",
+					category, country, share)
+			}
+		}
+		if country == global {
+			b.global = shares
+			continue
+		}
+		b.countries[strings.ToUpper(country)] = shares
+	}
+
+	return b, nil
+}
+
+// Load reads the baseline from the file.
+func Load(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return New(f)
+}
+
+// Extend provides the baseline with the global shares of the other baseline categories and the shares
+// of the other baseline countries replacing those of the same categories and countries.
+func (b *Baseline) Extend(other *Baseline) *Baseline {
+	ext := &Baseline{global: map[string]float64{}, countries: map[string]map[string]float64{}}
+	for _, bl := range []*Baseline{b, other} {
+		if bl == nil {
+			continue
+		}
+		for category, share := range bl.global {
+			ext.global[category] = share
+		}
+		for country, shares := range bl.countries {
+			ext.countries[country] = shares
+		}
+	}
+	return ext
+}
+
+// Share provides the baseline share of the category in the country, the global share if the country
+// baseline does not have the category or MinShare if neither has it.
+func (b *Baseline) Share(country, category string) float64 {
+	if b == nil {
+		return MinShare
+	}
+	if share, ok := b.countries[strings.ToUpper(country)][category]; ok {
+		return share
+	}
+	if share, ok := b.global[category]; ok {
+		return share
+	}
+	return MinShare
+}
+
+// Distinctiveness of the category with the share (0-1) of the places near the photos in the country.
+// It is positive for the categories more common than usual. A nil baseline provides the share.
+func (b *Baseline) Distinctiveness(country, category string, share float64) float64 {
+	if b == nil {
+		return share
+	}
+	if share <= 0 {
+		return 0
+	}
+	return share * math.Log(share/b.Share(country, category))
+}
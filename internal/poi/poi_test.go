@@ -0,0 +1,78 @@
+// +build unit_tests
+
+package poi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+)
+
+func TestBaseline_Share(t *testing.T) {
+	b, err := poi.New(strings.NewReader(`{
+		"global": {"Restaurants": 0.3, "Pubs": 0.05, "Botanical Gardens": 0.01},
+		"gbr": {"Pubs": 0.2}
+	}`))
+	require.NoError(t, err)
+
+	require.Equal(t, 0.2, b.Share("GBR", "Pubs"))
+	require.Equal(t, 0.05, b.Share("ITA", "Pubs"))
+	require.Equal(t, 0.05, b.Share("", "Pubs"))
+	// the country baseline falls back to the global one.
+	require.Equal(t, 0.3, b.Share("GBR", "Restaurants"))
+	require.Equal(t, poi.MinShare, b.Share("GBR", "Lighthouses"))
+
+	var nilBaseline *poi.Baseline
+	require.Equal(t, poi.MinShare, nilBaseline.Share("GBR", "Pubs"))
+}
+
+func TestBaseline_Distinctiveness(t *testing.T) {
+	b := poi.Default()
+
+	// many restaurants are less distinctive than a few botanical gardens.
+	restaurants := b.Distinctiveness("", "Restaurants", 0.35)
+	gardens := b.Distinctiveness("", "Botanical Gardens", 0.05)
+	require.Greater(t, gardens, restaurants)
+	require.Greater(t, restaurants, 0.0)
+
+	// less common than usual.
+	require.Less(t, b.Distinctiveness("", "Restaurants", 0.1), 0.0)
+	require.Equal(t, 0.0, b.Distinctiveness("", "Restaurants", 0))
+
+	// pubs are everyday places in the UK.
+	require.Greater(t, b.Distinctiveness("ITA", "Pubs", 0.15), b.Distinctiveness("GBR", "Pubs", 0.15))
+
+	var nilBaseline *poi.Baseline
+	require.Equal(t, 0.35, nilBaseline.Distinctiveness("", "Restaurants", 0.35))
+}
+
+func TestBaseline_Extend(t *testing.T) {
+	b, err := poi.New(strings.NewReader(`{"global": {"Restaurants": 0.5}, "GBR": {"Restaurants": 0.4}}`))
+	require.NoError(t, err)
+
+	ext := poi.Default().Extend(b)
+	require.Equal(t, 0.5, ext.Share("", "Restaurants"))
+	require.Equal(t, 0.4, ext.Share("GBR", "Restaurants"))
+	// the country baseline is replaced.
+	require.Equal(t, poi.Default().Share("", "Pubs"), ext.Share("GBR", "Pubs"))
+	require.Equal(t, poi.Default().Share("", "Cafes"), ext.Share("", "Cafes"))
+
+	// the extended baseline does not change.
+	require.Equal(t, 0.3, poi.Default().Share("", "Restaurants"))
+	require.Equal(t, poi.Default().Share("", "Cafes"), poi.Default().Extend(nil).Share("", "Cafes"))
+}
+
+func TestNew_Invalid(t *testing.T) {
+	for _, baseline := range []string{
+		`{"global": {"Restaurants": 0}}`,
+		`{"global": {"Restaurants": 1.5}}`,
+		`{"GBR": {"Pubs": -0.1}}`,
+		`{"global": ["Restaurants"]}`,
+		`{"global": `,
+	} {
+		_, err := poi.New(strings.NewReader(baseline))
+		require.Error(t, err, baseline)
+	}
+}
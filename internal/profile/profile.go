@@ -0,0 +1,274 @@
+// Package profile fits the headings to the constraints of the platforms they are published on,
+// eg the length of the page titles or of the newsletter subjects.
+package profile
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Casing style of the headings.
+type Casing string
+
+// Casing styles.
+const (
+	// AsIs keeps the casing of the headings.
+	AsIs Casing = ""
+	// TitleCase capitalizes all words but the minor ones (articles, prepositions, conjunctions).
+	TitleCase Casing = "title"
+	// SentenceCase capitalizes the first word and the proper names only.
+	SentenceCase Casing = "sentence"
+)
+
+// Profile of the platform the headings are published on.
+type Profile struct {
+	Name string
+	// MaxLength is the most characters of the heading. Longer headings are shortened
+	// or dropped if they cannot be shortened.
+	MaxLength int
+	Casing    Casing
+	// Banned characters are removed from the headings.
+	Banned string
+	// SlugLength is the most characters of the URL slug.
+	SlugLength int
+}
+
+// Builtin profiles.
+var (
+	SEOTitle          = Profile{Name: "seo-title", MaxLength: 60, Casing: TitleCase, Banned: `|<>"`, SlugLength: 60}
+	OGTitle           = Profile{Name: "og-title", MaxLength: 90, Casing: SentenceCase, Banned: `<>"`, SlugLength: 75}
+	Tweet             = Profile{Name: "tweet", MaxLength: 257, Banned: `<>`, SlugLength: 60}
+	NewsletterSubject = Profile{
+		Name: "newsletter-subject", MaxLength: 50, Casing: SentenceCase, Banned: `!$%<>"`, SlugLength: 50,
+	}
+)
+
+// Builtin lists the builtin profiles. The tweet leaves room for a link (23 characters).
+func Builtin() []Profile {
+	return []Profile{SEOTitle, OGTitle, Tweet, NewsletterSubject}
+}
+
+// Lookup provides the builtin profile.
+func Lookup(name string) (Profile, error) {
+	names := []string{}
+	for _, p := range Builtin() {
+		if p.Name == name {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return Profile{}, errors.Errorf("unknown profile %q: expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Parse provides the builtin profiles of the comma separated names.
+func Parse(names string) ([]Profile, error) {
+	profiles := []Profile{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, err := Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// Heading is a heading fitted to the profile, with its compliance.
+type Heading struct {
+	Profile string
+	// Original is the heading as suggested.
+	Original string
+	// Heading is the heading fitted to the profile, empty if it was dropped.
+	Heading string
+	Slug    string
+	// Length (characters) of the Heading and the MaxLength of the profile.
+	Length    int
+	MaxLength int
+	// Compliant is true if the Original heading complies with the profile without any changes.
+	Compliant bool
+	Shortened bool
+	// Dropped is true if the heading could not be shortened to fit the profile.
+	Dropped bool
+	// Issues describe the changes made to fit the profile.
+	Issues []string
+}
+
+// minWords of a shortened heading.
+const minWords = 3
+
+// connectors start the trailing clauses, that can be left out, eg "... in Sorrento packed with Bars".
+var connectors = words(`with of in on for at full packed bursting brimming enjoying during from to and
+	mit voller im in von nach und di a con nella nel per e de en con la el y`)
+
+// minor words are not capitalized in title case and do not end a shortened heading.
+var minor = words(`a an the and or but of with in on for at to from by as
+	mit im in von nach und di a con nella nel per e de en con la el y`)
+
+// Apply fits the heading to the profile. Proper names (eg the city) keep their
+// capitalization in sentence case.
+func (p Profile) Apply(heading string, proper ...string) Heading {
+	h := Heading{Profile: p.Name, Original: heading, MaxLength: p.MaxLength}
+
+	fitted := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(p.Banned, r) {
+			return ' '
+		}
+		return r
+	}, heading)
+	fitted = strings.Join(strings.Fields(fitted), " ")
+	if fitted != heading {
+		h.Issues = append(h.Issues, "banned characters removed")
+	}
+
+	if cased := p.Casing.apply(fitted, proper); cased != fitted {
+		fitted = cased
+		h.Issues = append(h.Issues, string(p.Casing)+" case applied")
+	}
+
+	if p.MaxLength > 0 && utf8.RuneCountInString(fitted) > p.MaxLength {
+		shortened, ok := shorten(fitted, p.MaxLength)
+		if !ok {
+			h.Dropped = true
+			h.Issues = append(h.Issues, "too long to be shortened")
+			return h
+		}
+		fitted = shortened
+		h.Shortened = true
+		h.Issues = append(h.Issues, "shortened")
+	}
+
+	h.Heading = fitted
+	h.Length = utf8.RuneCountInString(fitted)
+	h.Slug = Slug(fitted, p.SlugLength)
+	h.Compliant = len(h.Issues) == 0
+
+	return h
+}
+
+// shorten leaves out the trailing clauses of the heading, or cuts it at a word boundary
+// if that is not enough. Headings shorter than minWords cannot be shortened.
+func shorten(heading string, maxLength int) (string, bool) {
+	ws := strings.Fields(heading)
+
+	// the longest heading without trailing clauses that fits.
+	for i := len(ws) - 1; i >= minWords; i-- {
+		if !connectors[strings.ToLower(ws[i])] {
+			continue
+		}
+		if s := trimDangling(ws[:i]); len(s) >= minWords && fits(s, maxLength) {
+			return strings.Join(s, " "), true
+		}
+	}
+
+	for i := len(ws) - 1; i >= minWords; i-- {
+		if s := trimDangling(ws[:i]); len(s) >= minWords && fits(s, maxLength) {
+			return strings.Join(s, " "), true
+		}
+	}
+	return "", false
+}
+
+// trimDangling leaves out the minor words and punctuation ending the heading.
+func trimDangling(ws []string) []string {
+	for len(ws) > 0 {
+		last := strings.TrimRight(ws[len(ws)-1], ",;:-–")
+		if last != "" && !minor[strings.ToLower(last)] {
+			return append(append([]string{}, ws[:len(ws)-1]...), last)
+		}
+		ws = ws[:len(ws)-1]
+	}
+	return ws
+}
+
+func fits(ws []string, maxLength int) bool {
+	return utf8.RuneCountInString(strings.Join(ws, " ")) <= maxLength
+}
+
+func (c Casing) apply(heading string, proper []string) string {
+	names := map[string]bool{}
+	for _, p := range proper {
+		for _, w := range strings.Fields(p) {
+			names[w] = true
+		}
+	}
+
+	ws := strings.Fields(heading)
+	for i, w := range ws {
+		switch {
+		case c == TitleCase && (i == 0 || !minor[strings.ToLower(w)]):
+			ws[i] = capitalize(w)
+		case c == TitleCase:
+			ws[i] = strings.ToLower(w)
+		case c == SentenceCase && i == 0:
+			ws[i] = capitalize(w)
+		case c == SentenceCase && !names[w] && !isAcronym(w):
+			ws[i] = strings.ToLower(w)
+		}
+	}
+	return strings.Join(ws, " ")
+}
+
+// Slug provides the URL slug of the heading: lower case ASCII letters and digits separated
+// by hyphens, at most maxLength characters (cut at a word boundary).
+func Slug(heading string, maxLength int) string {
+	b := &strings.Builder{}
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+		case r == '\'' || r == '’':
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	slug := ""
+	for _, w := range strings.Fields(b.String()) {
+		next := w
+		if slug != "" {
+			next = slug + "-" + w
+		}
+		if maxLength > 0 && len(next) > maxLength {
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ä': "ae", 'ã': "a", 'å': "a",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'ö': "oe", 'õ': "o",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "ue",
+	'ß': "ss",
+}
+
+func capitalize(w string) string {
+	r, size := utf8.DecodeRuneInString(w)
+	return string(unicode.ToUpper(r)) + w[size:]
+}
+
+func isAcronym(w string) bool {
+	return utf8.RuneCountInString(w) > 1 && strings.ToUpper(w) == w && strings.ToLower(w) != w
+}
+
+func words(s string) map[string]bool {
+	m := map[string]bool{}
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
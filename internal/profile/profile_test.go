@@ -0,0 +1,172 @@
+// +build unit_tests
+
+package profile_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/profile"
+)
+
+func TestProfile_Apply(t *testing.T) {
+	proper := []string{"Italy", "Sorrento", "October", "Amalfi Coast"}
+
+	tests := []struct {
+		name    string
+		profile profile.Profile
+		heading string
+		want    profile.Heading
+	}{
+		{
+			name:    "compliant",
+			profile: profile.Tweet,
+			heading: "Wonderful break in Sorrento packed with Bars",
+			want: profile.Heading{
+				Profile:   "tweet",
+				Original:  "Wonderful break in Sorrento packed with Bars",
+				Heading:   "Wonderful break in Sorrento packed with Bars",
+				Slug:      "wonderful-break-in-sorrento-packed-with-bars",
+				Length:    44,
+				MaxLength: 257,
+				Compliant: true,
+			},
+		},
+		{
+			name:    "title case",
+			profile: profile.SEOTitle,
+			heading: "Wonderful break in sunny Sorrento full of the Bars",
+			want: profile.Heading{
+				Profile:   "seo-title",
+				Original:  "Wonderful break in sunny Sorrento full of the Bars",
+				Heading:   "Wonderful Break in Sunny Sorrento Full of the Bars",
+				Slug:      "wonderful-break-in-sunny-sorrento-full-of-the-bars",
+				Length:    50,
+				MaxLength: 60,
+				Issues:    []string{"title case applied"},
+			},
+		},
+		{
+			name:    "sentence case keeps proper names",
+			profile: profile.OGTitle,
+			heading: "Brilliant October on the Amalfi Coast in Sunny Italy",
+			want: profile.Heading{
+				Profile:   "og-title",
+				Original:  "Brilliant October on the Amalfi Coast in Sunny Italy",
+				Heading:   "Brilliant October on the Amalfi Coast in sunny Italy",
+				Slug:      "brilliant-october-on-the-amalfi-coast-in-sunny-italy",
+				Length:    52,
+				MaxLength: 90,
+				Issues:    []string{"sentence case applied"},
+			},
+		},
+		{
+			name:    "banned characters removed",
+			profile: profile.SEOTitle,
+			heading: `Sorrento | "Sunny" Days`,
+			want: profile.Heading{
+				Profile:   "seo-title",
+				Original:  `Sorrento | "Sunny" Days`,
+				Heading:   "Sorrento Sunny Days",
+				Slug:      "sorrento-sunny-days",
+				Length:    19,
+				MaxLength: 60,
+				Issues:    []string{"banned characters removed"},
+			},
+		},
+		{
+			name:    "trailing clause left out",
+			profile: profile.NewsletterSubject,
+			heading: "Enjoy happy days with family in sunny Sorrento packed with Bars",
+			want: profile.Heading{
+				Profile:   "newsletter-subject",
+				Original:  "Enjoy happy days with family in sunny Sorrento packed with Bars",
+				Heading:   "Enjoy happy days with family in sunny Sorrento",
+				Slug:      "enjoy-happy-days-with-family-in-sunny-sorrento",
+				Length:    46,
+				MaxLength: 50,
+				Shortened: true,
+				Issues:    []string{"sentence case applied", "shortened"},
+			},
+		},
+		{
+			name:    "cut at a word boundary",
+			profile: profile.Profile{Name: "short", MaxLength: 35},
+			heading: "Unforgettable Sorrento, Positano, Amalfi and Capri",
+			want: profile.Heading{
+				Profile:   "short",
+				Original:  "Unforgettable Sorrento, Positano, Amalfi and Capri",
+				Heading:   "Unforgettable Sorrento, Positano",
+				Slug:      "unforgettable-sorrento-positano",
+				Length:    32,
+				MaxLength: 35,
+				Shortened: true,
+				Issues:    []string{"shortened"},
+			},
+		},
+		{
+			name:    "dropped",
+			profile: profile.Profile{Name: "tiny", MaxLength: 10},
+			heading: "Wonderful break in Sorrento",
+			want: profile.Heading{
+				Profile:   "tiny",
+				Original:  "Wonderful break in Sorrento",
+				MaxLength: 10,
+				Dropped:   true,
+				Issues:    []string{"too long to be shortened"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.profile.Apply(tt.heading, proper...))
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name      string
+		heading   string
+		maxLength int
+		want      string
+	}{
+		{
+			name:    "punctuation",
+			heading: "Ferragosto: L'estate a Sorrento!",
+			want:    "ferragosto-lestate-a-sorrento",
+		},
+		{
+			name:    "transliterated",
+			heading: "Schöne Tage im sonnigen Süden, Nápoles",
+			want:    "schoene-tage-im-sonnigen-sueden-napoles",
+		},
+		{
+			name:      "cut at a word boundary",
+			heading:   "Wonderful break in Sorrento packed with Bars",
+			maxLength: 30,
+			want:      "wonderful-break-in-sorrento",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, profile.Slug(tt.heading, tt.maxLength))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	profiles, err := profile.Parse("seo-title, tweet")
+	require.NoError(t, err)
+	require.Equal(t, []profile.Profile{profile.SEOTitle, profile.Tweet}, profiles)
+
+	profiles, err = profile.Parse("")
+	require.NoError(t, err)
+	require.Empty(t, profiles)
+
+	_, err = profile.Parse("seo-title,billboard")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown profile")
+}
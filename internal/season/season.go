@@ -0,0 +1,194 @@
+// Package season resolves the season of the photo date and position.
+//
+// Seasons are flipped on the southern hemisphere. The tropics have wet and dry seasons
+// instead. The calendar of the temperate seasons is either meteorological (seasons start
+// on the first day of March, June, September and December) or astronomical (seasons start
+// on the equinoxes and solstices). A custom calendar provides the seasons by country code
+// and month, and falls back to the meteorological calendar for countries not in the table.
+package season
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Calendar of the seasons.
+type Calendar string
+
+// Calendars.
+const (
+	Meteorological Calendar = "meteorological"
+	Astronomical   Calendar = "astronomical"
+	Custom         Calendar = "custom"
+)
+
+// Seasons.
+const (
+	Spring = "Spring"
+	Summer = "Summer"
+	Autumn = "Autumn"
+	Winter = "Winter"
+	Wet    = "Wet season"
+	Dry    = "Dry season"
+)
+
+// Tropics is the latitude of the tropics of Cancer and Capricorn.
+const Tropics = 23.44
+
+// Table holds the custom seasons by ISO 3166-1 alpha-3 country code and month.
+type Table map[string]map[time.Month]string
+
+// Resolver resolves seasons with the calendar. The zero value uses the meteorological calendar.
+type Resolver struct {
+	Calendar Calendar
+	// Table is used with the custom calendar.
+	Table Table
+}
+
+// Default resolver.
+var Default = Resolver{Calendar: Meteorological}
+
+// ParseCalendar validates the calendar. An empty calendar means Meteorological.
+func ParseCalendar(s string) (Calendar, error) {
+	switch c := Calendar(s); c {
+	case "":
+		return Meteorological, nil
+	case Meteorological, Astronomical, Custom:
+		return c, nil
+	default:
+		return "", errors.Errorf("unknown season calendar %q: expected meteorological, astronomical or custom", s)
+	}
+}
+
+// Season provides the season of the local date at the latitude in the country (ISO 3166-1 alpha-3 code).
+func (r Resolver) Season(t time.Time, lat float64, countryCode string) string {
+	if r.Calendar == Custom {
+		if s, ok := r.Table[countryCode][t.Month()]; ok && s != "" {
+			return s
+		}
+	}
+
+	south := lat < 0
+	if math.Abs(lat) < Tropics {
+		return tropical(t.Month(), south)
+	}
+	if r.Calendar == Astronomical {
+		return astronomical(t, south)
+	}
+	return meteorological(t.Month(), south)
+}
+
+// tropical seasons: the wet season is May to October north of the equator
+// and November to April south of it.
+func tropical(m time.Month, south bool) string {
+	wet := m >= time.May && m <= time.October
+	if south {
+		wet = !wet
+	}
+	if wet {
+		return Wet
+	}
+	return Dry
+}
+
+var northern = []string{Winter, Spring, Summer, Autumn}
+
+func meteorological(m time.Month, south bool) string {
+	// December is the first month of winter.
+	return flip(northern[int(m)%12/3], south)
+}
+
+// astronomical seasons start on the (approximate) equinox and solstice days.
+func astronomical(t time.Time, south bool) string {
+	starts := []struct {
+		m time.Month
+		d int
+	}{
+		{time.March, 20}, {time.June, 21}, {time.September, 22}, {time.December, 21},
+	}
+
+	i := 0
+	for _, s := range starts {
+		if t.Month() > s.m || (t.Month() == s.m && t.Day() >= s.d) {
+			i++
+		}
+	}
+	return flip(northern[i%4], south)
+}
+
+func flip(s string, south bool) string {
+	if !south {
+		return s
+	}
+	switch s {
+	case Spring:
+		return Autumn
+	case Summer:
+		return Winter
+	case Autumn:
+		return Spring
+	default:
+		return Summer
+	}
+}
+
+// ReadTable reads the custom seasons table by ISO 3166-1 alpha-3 country code in JSON format, eg:
+//
+//	{"THA": {"November": "Cool season", "March": "Hot season", "July": "Rainy season", ...}}
+func ReadTable(r io.Reader) (Table, error) {
+	raw := map[string]map[string]string{}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, errors.Wrap(err, "invalid season table")
+	}
+
+	months := map[string]time.Month{}
+	for m := time.January; m <= time.December; m++ {
+		months[m.String()] = m
+	}
+
+	table := Table{}
+	for country, seasons := range raw {
+		if !isCountryCode(country) {
+			return nil, errors.Errorf("invalid season table: country %q: expected ISO 3166-1 alpha-3 code, eg THA",
+				country)
+		}
+		table[country] = map[time.Month]string{}
+		for name, s := range seasons {
+			m, ok := months[name]
+			if !ok {
+				return nil, errors.Errorf("invalid season table: country %s: unknown month %q", country, name)
+			}
+			table[country][m] = s
+		}
+	}
+
+	return table, nil
+}
+
+func isCountryCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadTable reads the custom seasons table from the file.
+func LoadTable(path string) (Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadTable(f)
+}
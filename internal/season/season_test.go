@@ -0,0 +1,143 @@
+// +build unit_tests
+
+package season_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/season"
+)
+
+func TestResolver_Season(t *testing.T) {
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(2020, m, d, 12, 0, 0, 0, time.UTC)
+	}
+	thailand := season.Table{"THA": {time.March: "Hot season", time.July: "Rainy season"}}
+
+	tests := []struct {
+		name     string
+		resolver season.Resolver
+		t        time.Time
+		lat      float64
+		country  string
+		want     string
+	}{
+		{name: "Sorrento in January", resolver: season.Default, t: date(time.January, 10), lat: 40.626,
+			want: season.Winter},
+		{name: "Sydney in January", resolver: season.Default, t: date(time.January, 10), lat: -33.869,
+			want: season.Summer},
+		{name: "Prague on the 1st of December", resolver: season.Default, t: date(time.December, 1), lat: 50.075,
+			want: season.Winter},
+		{name: "Cape Town in April", resolver: season.Default, t: date(time.April, 15), lat: -33.925,
+			want: season.Autumn},
+		{name: "zero value resolver", t: date(time.July, 15), lat: 51.507, want: season.Summer},
+
+		// tropics
+		{name: "Bangkok in July", resolver: season.Default, t: date(time.July, 10), lat: 13.756,
+			want: season.Wet},
+		{name: "Bangkok in January", resolver: season.Default, t: date(time.January, 10), lat: 13.756,
+			want: season.Dry},
+		{name: "Darwin in January", resolver: season.Default, t: date(time.January, 10), lat: -12.463,
+			want: season.Wet},
+		{name: "equator in October", resolver: season.Default, t: date(time.October, 10), lat: 0,
+			want: season.Wet},
+		{name: "just outside of the tropics", resolver: season.Default, t: date(time.July, 10), lat: 23.5,
+			want: season.Summer},
+
+		// astronomical calendar
+		{name: "astronomical day before the solstice", resolver: season.Resolver{Calendar: season.Astronomical},
+			t: date(time.June, 20), lat: 40.626, want: season.Spring},
+		{name: "astronomical solstice", resolver: season.Resolver{Calendar: season.Astronomical},
+			t: date(time.June, 21), lat: 40.626, want: season.Summer},
+		{name: "astronomical winter solstice", resolver: season.Resolver{Calendar: season.Astronomical},
+			t: date(time.December, 21), lat: 40.626, want: season.Winter},
+		{name: "astronomical early December", resolver: season.Resolver{Calendar: season.Astronomical},
+			t: date(time.December, 1), lat: 40.626, want: season.Autumn},
+		{name: "astronomical southern equinox", resolver: season.Resolver{Calendar: season.Astronomical},
+			t: date(time.March, 20), lat: -33.869, want: season.Autumn},
+
+		// custom calendar
+		{name: "custom table", resolver: season.Resolver{Calendar: season.Custom, Table: thailand},
+			t: date(time.July, 10), lat: 13.756, country: "THA", want: "Rainy season"},
+		{name: "custom table missing month", resolver: season.Resolver{Calendar: season.Custom, Table: thailand},
+			t: date(time.January, 10), lat: 13.756, country: "THA", want: season.Dry},
+		{name: "custom table missing country", resolver: season.Resolver{Calendar: season.Custom, Table: thailand},
+			t: date(time.January, 10), lat: 40.626, country: "ITA", want: season.Winter},
+		{name: "table ignored by other calendars", resolver: season.Resolver{Calendar: season.Meteorological,
+			Table: thailand}, t: date(time.July, 10), lat: 13.756, country: "THA", want: season.Wet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.resolver.Season(tt.t, tt.lat, tt.country))
+		})
+	}
+}
+
+func TestParseCalendar(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    season.Calendar
+		wantErr bool
+	}{
+		{in: "", want: season.Meteorological},
+		{in: "meteorological", want: season.Meteorological},
+		{in: "astronomical", want: season.Astronomical},
+		{in: "custom", want: season.Custom},
+		{in: "lunar", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := season.ParseCalendar(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    season.Table
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `{"THA": {"March": "Hot season", "November": "Cool season"}}`,
+			want: season.Table{"THA": {time.March: "Hot season", time.November: "Cool season"}},
+		},
+		{
+			name:    "unknown month",
+			data:    `{"THA": {"Mar": "Hot season"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "country name instead of the code",
+			data:    `{"Thailand": {"March": "Hot season"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			data:    `{"THA": `,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := season.ReadTable(strings.NewReader(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
+)
+
+// maxUploadSize limits the size of the article request body.
+const maxUploadSize = 10 << 20
+
+type (
+	articleRequest struct {
+		Name   string         `json:"name"`
+		Photos []photoRequest `json:"photos"`
+	}
+	photoRequest struct {
+		Date      string      `json:"date"`
+		Latitude  json.Number `json:"latitude"`
+		Longitude json.Number `json:"longitude"`
+	}
+
+	articleResponse struct {
+		Name     string            `json:"name"`
+		Headings []string          `json:"headings"`
+		Scores   []scoreResponse   `json:"scores"`
+		Profiled []profileResponse `json:"profiled,omitempty"`
+		Summary  summaryResponse   `json:"summary"`
+	}
+	profileResponse struct {
+		Profile   string   `json:"profile"`
+		Original  string   `json:"original"`
+		Heading   string   `json:"heading"`
+		Slug      string   `json:"slug"`
+		Length    int      `json:"length"`
+		MaxLength int      `json:"max_length"`
+		Compliant bool     `json:"compliant"`
+		Shortened bool     `json:"shortened"`
+		Dropped   bool     `json:"dropped"`
+		Issues    []string `json:"issues,omitempty"`
+	}
+	scoreResponse struct {
+		Heading     string  `json:"heading"`
+		Score       float64 `json:"score"`
+		Specificity float64 `json:"specificity"`
+		Confidence  float64 `json:"confidence"`
+		Length      float64 `json:"length"`
+		Readability float64 `json:"readability"`
+		Redundancy  float64 `json:"redundancy"`
+	}
+	summaryResponse struct {
+		Country          string         `json:"country"`
+		City             string         `json:"city"`
+		Weather          string         `json:"weather"`
+		Weekday          string         `json:"weekday"`
+		Month            string         `json:"month"`
+		Season           string         `json:"season"`
+		TimeOfDay        string         `json:"time_of_day"`
+		PlaceOfInterest  string         `json:"place_of_interest"`
+		PlacesOfInterest []string       `json:"places_of_interest,omitempty"`
+		Holiday          string         `json:"holiday,omitempty"`
+		Landmark         string         `json:"landmark,omitempty"`
+		Itinerary        []string       `json:"itinerary,omitempty"`
+		Region           string         `json:"region,omitempty"`
+		Focus            *areaResponse  `json:"focus,omitempty"`
+		Duration         string         `json:"duration,omitempty"`
+		Days             int            `json:"days"`
+		Tone             string         `json:"tone,omitempty"`
+		Shares           sharesResponse `json:"shares"`
+		Photos           int            `json:"photos"`
+		Locations        int            `json:"locations"`
+		Weathers         int            `json:"weathers"`
+		Pois             int            `json:"pois"`
+		Errors           []string       `json:"errors,omitempty"`
+	}
+
+	areaResponse struct {
+		Latitude  float64 `json:"lat"`
+		Longitude float64 `json:"lon"`
+		Spread    float64 `json:"spread_km"`
+	}
+
+	sharesResponse struct {
+		Country         float64 `json:"country"`
+		City            float64 `json:"city"`
+		Weather         float64 `json:"weather"`
+		Weekday         float64 `json:"weekday"`
+		Month           float64 `json:"month"`
+		Season          float64 `json:"season"`
+		TimeOfDay       float64 `json:"time_of_day"`
+		PlaceOfInterest float64 `json:"place_of_interest"`
+		Holiday         float64 `json:"holiday"`
+		Landmark        float64 `json:"landmark"`
+	}
+
+	statusResponse struct {
+		Status string `json:"status"`
+	}
+	errorResponse struct {
+		Error   string           `json:"error"`
+		Summary *summaryResponse `json:"summary,omitempty"`
+	}
+)
+
+// handleArticles suggests headings for the article photos provided either as JSON photo records,
+// a CSV body (text/csv) or a CSV file uploaded as multipart/form-data (field "article").
+// The request is processed asynchronously when the async query parameter is true. The tone query
+// parameter sets the editorial tone of the article headings, eg family or auto.
+func (s *Server) handleArticles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	name, photoL, err := readArticle(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(photoL) == 0 {
+		writeError(w, http.StatusBadRequest, "no photo records provided")
+		return
+	}
+
+	as := s.service
+	if t := r.URL.Query().Get("tone"); t != "" {
+		if err := tone.Validate(t); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		as.Tone = t
+	}
+
+	async, _ := strconv.ParseBool(r.URL.Query().Get("async"))
+	if async {
+		j := s.jobs.create()
+		s.running.Add(1)
+		go s.runJob(as, j.ID, name, photoL)
+
+		w.Header().Set("Location", "/v1/jobs/"+j.ID)
+		writeJSON(w, http.StatusAccepted, j)
+		return
+	}
+
+	article, err := as.ProcessArticle(r.Context(), name, photoL)
+	if err != nil {
+		status := http.StatusUnprocessableEntity
+		if !errors.Is(err, service.ErrNoAdditionalInfo) {
+			// processing was aborted because of a provider failure.
+			status = http.StatusBadGateway
+		}
+		sr := toSummaryResponse(article.Summary)
+		writeJSON(w, status, errorResponse{Error: err.Error(), Summary: &sr})
+		return
+	}
+	writeJSON(w, http.StatusOK, toArticleResponse(article))
+}
+
+func (s *Server) runJob(as service.ArticleService, id, name string, photoL []photo.Data) {
+	defer s.running.Done()
+	s.jobs.update(id, func(j *job) { j.Status = jobRunning })
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.jobTimeout)
+	defer cancel()
+	article, err := as.ProcessArticle(ctx, name, photoL)
+
+	s.jobs.update(id, func(j *job) {
+		res := toArticleResponse(article)
+		j.Result = &res
+		j.Status = jobDone
+		j.finishedAt = time.Now()
+		if err != nil {
+			j.Status = jobFailed
+			j.Error = err.Error()
+		}
+	})
+}
+
+func readArticle(r *http.Request) (string, []photo.Data, error) {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "invalid Content-Type")
+	}
+	name := r.URL.Query().Get("name")
+
+	switch mt {
+	case "application/json":
+		req := articleRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return "", nil, errors.Wrap(err, "invalid article request")
+		}
+		if req.Name != "" {
+			name = req.Name
+		}
+		if name == "" {
+			name = "article"
+		}
+		return name, toPhotoData(name, req.Photos), nil
+	case "text/csv":
+		if name == "" {
+			name = "article"
+		}
+		photoL, err := service.ParsePhotoData(r.Context(), name, r.Body)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "invalid article CSV")
+		}
+		return name, photoL, nil
+	case "multipart/form-data":
+		f, fh, err := r.FormFile("article")
+		if err != nil {
+			return "", nil, errors.Wrap(err, "invalid article upload")
+		}
+		defer f.Close()
+
+		if name == "" {
+			name = fh.Filename
+		}
+		photoL, err := service.ParsePhotoData(r.Context(), name, f)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "invalid article CSV")
+		}
+		return name, photoL, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported Content-Type %s", mt)
+	}
+}
+
+func toPhotoData(name string, photos []photoRequest) []photo.Data {
+	photoL := []photo.Data{}
+	for i, p := range photos {
+		photoL = append(photoL, photo.Data{
+			ArticleID: name,
+			ID:        i + 1,
+			Date:      p.Date,
+			LatLon: photo.LatLon{
+				Latitude:  p.Latitude.String(),
+				Longitude: p.Longitude.String(),
+			},
+		})
+	}
+	return photoL
+}
+
+func toArticleResponse(a service.Article) articleResponse {
+	headings := a.Headings
+	if headings == nil {
+		headings = []string{}
+	}
+	scores := []scoreResponse{}
+	for _, sh := range a.Scores {
+		scores = append(scores, scoreResponse{
+			Heading:     sh.Heading,
+			Score:       sh.Score,
+			Specificity: sh.Specificity,
+			Confidence:  sh.Confidence,
+			Length:      sh.Length,
+			Readability: sh.Readability,
+			Redundancy:  sh.Redundancy,
+		})
+	}
+	profiled := []profileResponse{}
+	for _, ph := range a.Profiled {
+		profiled = append(profiled, profileResponse{
+			Profile:   ph.Profile,
+			Original:  ph.Original,
+			Heading:   ph.Heading,
+			Slug:      ph.Slug,
+			Length:    ph.Length,
+			MaxLength: ph.MaxLength,
+			Compliant: ph.Compliant,
+			Shortened: ph.Shortened,
+			Dropped:   ph.Dropped,
+			Issues:    ph.Issues,
+		})
+	}
+	return articleResponse{
+		Name:     a.Name,
+		Headings: headings,
+		Scores:   scores,
+		Profiled: profiled,
+		Summary:  toSummaryResponse(a.Summary),
+	}
+}
+
+func toAreaResponse(a *service.Area) *areaResponse {
+	if a == nil {
+		return nil
+	}
+	return &areaResponse{Latitude: a.Latitude, Longitude: a.Longitude, Spread: a.Spread}
+}
+
+func toSummaryResponse(s service.Summary) summaryResponse {
+	return summaryResponse{
+		Country:          s.Country,
+		City:             s.City,
+		Weather:          s.Weather,
+		Weekday:          s.Weekday,
+		Month:            s.Month,
+		Season:           s.Season,
+		TimeOfDay:        s.TimeOfDay,
+		PlaceOfInterest:  s.PlaceOfInterest,
+		PlacesOfInterest: s.PlacesOfInterest,
+		Holiday:          s.Holiday,
+		Landmark:         s.Landmark,
+		Itinerary:        s.Itinerary,
+		Region:           s.Region,
+		Focus:            toAreaResponse(s.Focus),
+		Duration:         s.Duration,
+		Days:             s.Days,
+		Tone:             s.Tone,
+		Shares:           sharesResponse(s.Shares),
+		Photos:           s.Photos,
+		Locations:        s.Locations,
+		Weathers:         s.Weathers,
+		Pois:             s.Pois,
+		Errors:           s.Errors,
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type jobStatus string
+
+const (
+	jobPending jobStatus = "pending"
+	jobRunning jobStatus = "running"
+	jobDone    jobStatus = "done"
+	jobFailed  jobStatus = "failed"
+)
+
+// job is an asynchronously processed article request.
+type job struct {
+	ID        string           `json:"id"`
+	Status    jobStatus        `json:"status"`
+	CreatedAt time.Time        `json:"created_at"`
+	Result    *articleResponse `json:"result,omitempty"`
+	Error     string           `json:"error,omitempty"`
+
+	finishedAt time.Time
+}
+
+func (j *job) finished() bool {
+	return j.Status == jobDone || j.Status == jobFailed
+}
+
+// jobStore keeps the asynchronous jobs in memory. Finished jobs are kept for the ttl
+// and at most max jobs are kept, unless they are still pending or running.
+type jobStore struct {
+	mu   *sync.Mutex
+	jobs map[string]*job
+	ttl  time.Duration
+	max  int
+}
+
+func newJobStore(ttl time.Duration, max int) *jobStore {
+	return &jobStore{
+		mu:   &sync.Mutex{},
+		jobs: map[string]*job{},
+		ttl:  ttl,
+		max:  max,
+	}
+}
+
+func (js *jobStore) create() job {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.evict(time.Now())
+	j := &job{
+		ID:        newJobID(),
+		Status:    jobPending,
+		CreatedAt: time.Now().UTC(),
+	}
+	js.jobs[j.ID] = j
+
+	return *j
+}
+
+// evict removes the expired jobs and the oldest finished jobs over the limit, leaving room for a new job.
+func (js *jobStore) evict(now time.Time) {
+	finished := []*job{}
+	for id, j := range js.jobs {
+		if !j.finished() {
+			continue
+		}
+		if js.ttl > 0 && now.Sub(j.finishedAt) > js.ttl {
+			delete(js.jobs, id)
+			continue
+		}
+		finished = append(finished, j)
+	}
+	if js.max <= 0 || len(js.jobs) < js.max {
+		return
+	}
+
+	sort.Slice(finished, func(i, k int) bool {
+		return finished[i].finishedAt.Before(finished[k].finishedAt)
+	})
+	for _, j := range finished {
+		if len(js.jobs) < js.max {
+			return
+		}
+		delete(js.jobs, j.ID)
+	}
+}
+
+func (js *jobStore) get(id string) (job, bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	j, ok := js.jobs[id]
+	if !ok {
+		return job{}, false
+	}
+	return *j, true
+}
+
+func (js *jobStore) update(id string, fn func(j *job)) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	if j, ok := js.jobs[id]; ok {
+		fn(j)
+	}
+}
+
+// handleJob provides the status, and when finished the result, of an asynchronous request.
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/jobs/")
+	j, ok := s.jobs.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, j)
+}
+
+func newJobID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+// Server exposes article heading suggestions as an HTTP JSON API:
+//
+//	POST /v1/articles      suggests headings for photo records (JSON) or an uploaded CSV file
+//	GET  /v1/jobs/{id}     provides the status and the result of an asynchronous request
+//	GET  /healthz          liveness
+//	GET  /readyz           readiness
+type Server struct {
+	service service.ArticleService
+	jobs    *jobStore
+
+	// asynchronous jobs run with the server context, cancelled by Shutdown, for at most jobTimeout.
+	ctx        context.Context
+	cancel     context.CancelFunc
+	running    *sync.WaitGroup
+	jobTimeout time.Duration
+
+	ready int32
+}
+
+// Job defaults.
+const (
+	// DefaultJobTimeout bounds the processing of an asynchronous request.
+	DefaultJobTimeout = 5 * time.Minute
+	// DefaultJobTTL is how long the finished jobs are kept.
+	DefaultJobTTL = time.Hour
+	// DefaultMaxJobs is the most jobs kept. The oldest finished jobs are evicted first.
+	DefaultMaxJobs = 1000
+)
+
+// Option customizes the Server.
+type Option func(*Server)
+
+// WithJobTimeout bounds the processing of an asynchronous request.
+func WithJobTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		s.jobTimeout = d
+	}
+}
+
+// WithJobRetention keeps the finished jobs for the ttl and at most max jobs.
+func WithJobRetention(ttl time.Duration, max int) Option {
+	return func(s *Server) {
+		s.jobs.ttl = ttl
+		s.jobs.max = max
+	}
+}
+
+// New is a Server constructor.
+func New(as service.ArticleService, opts ...Option) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		service:    as,
+		jobs:       newJobStore(DefaultJobTTL, DefaultMaxJobs),
+		ctx:        ctx,
+		cancel:     cancel,
+		running:    &sync.WaitGroup{},
+		jobTimeout: DefaultJobTimeout,
+		ready:      1,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Shutdown cancels the asynchronous jobs and waits until they finish or the context is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Handler provides the API routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/articles", s.handleArticles)
+	mux.HandleFunc("/v1/jobs/", s.handleJob)
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/readyz", s.handleReady)
+
+	return mux
+}
+
+// SetReady changes the server readiness, eg to stop receiving traffic during shutdown.
+func (s *Server) SetReady(ready bool) {
+	var r int32
+	if ready {
+		r = 1
+	}
+	atomic.StoreInt32(&s.ready, r)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&s.ready) == 0 {
+		writeJSON(w, http.StatusServiceUnavailable, statusResponse{Status: "not ready"})
+		return
+	}
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ready"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("ERROR failure to write response: %s\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
@@ -0,0 +1,397 @@
+// +build service_tests
+
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/server"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+const articleCSV = `2019-10-27T13:27:58Z,40.647863,14.366958
+2019-10-27T13:17:24Z,40.648005,14.367228
+2019-10-27T13:13:09Z,40.647462,14.369295
+`
+
+type addrClientM struct{}
+type weathClientM struct{}
+type poiClientM struct{}
+type failingAddrClientM struct{}
+type blockingAddrClientM struct{}
+
+var errLocation = errors.New("location not found")
+
+type articleResponse struct {
+	Name     string   `json:"name"`
+	Headings []string `json:"headings"`
+	Scores   []struct {
+		Heading string  `json:"heading"`
+		Score   float64 `json:"score"`
+	} `json:"scores"`
+	Summary struct {
+		Country         string   `json:"country"`
+		City            string   `json:"city"`
+		Weather         string   `json:"weather"`
+		Weekday         string   `json:"weekday"`
+		Month           string   `json:"month"`
+		Season          string   `json:"season"`
+		PlaceOfInterest string   `json:"place_of_interest"`
+		Tone            string   `json:"tone"`
+		Photos          int      `json:"photos"`
+		Locations       int      `json:"locations"`
+		Errors          []string `json:"errors"`
+	} `json:"summary"`
+}
+
+type jobResponse struct {
+	ID     string           `json:"id"`
+	Status string           `json:"status"`
+	Result *articleResponse `json:"result"`
+	Error  string           `json:"error"`
+}
+
+func TestArticles_JSON(t *testing.T) {
+	ts := setup(addrClientM{})
+	defer ts.Close()
+
+	body := `{"name": "sorrento", "photos": [
+		{"date": "2019-10-27T13:27:58Z", "latitude": 40.647863, "longitude": 14.366958},
+		{"date": "2019-10-27T13:17:24Z", "latitude": "40.648005", "longitude": "14.367228"}
+	]}`
+	res, err := http.Post(ts.URL+"/v1/articles", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	got := articleResponse{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+
+	require.Equal(t, "sorrento", got.Name)
+	require.Len(t, got.Headings, 8)
+	require.Len(t, got.Scores, 8)
+	for i, sc := range got.Scores {
+		require.Equal(t, got.Headings[i], sc.Heading)
+		require.LessOrEqual(t, sc.Score, got.Scores[0].Score)
+	}
+	require.Equal(t, "Italy", got.Summary.Country)
+	require.Equal(t, "Sorrento", got.Summary.City)
+	require.Equal(t, "sunny", got.Summary.Weather)
+	require.Equal(t, "Sunday", got.Summary.Weekday)
+	require.Equal(t, "October", got.Summary.Month)
+	require.Equal(t, "Autumn", got.Summary.Season)
+	require.Equal(t, "Cafes", got.Summary.PlaceOfInterest)
+	require.Equal(t, 2, got.Summary.Photos)
+	require.Equal(t, 2, got.Summary.Locations)
+}
+
+func TestArticles_CSVUpload(t *testing.T) {
+	ts := setup(addrClientM{})
+	defer ts.Close()
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	fw, err := mw.CreateFormFile("article", "article1.csv")
+	require.NoError(t, err)
+	_, err = fw.Write([]byte(articleCSV))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	res, err := http.Post(ts.URL+"/v1/articles", mw.FormDataContentType(), body)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	got := articleResponse{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+
+	require.Equal(t, "article1.csv", got.Name)
+	require.Len(t, got.Headings, 8)
+	require.Equal(t, 3, got.Summary.Photos)
+}
+
+func TestArticles_AsyncJob(t *testing.T) {
+	ts := setup(addrClientM{})
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/v1/articles?async=true&name=article1", "text/csv", strings.NewReader(articleCSV))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+	j := jobResponse{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+	require.NotEmpty(t, j.ID)
+	require.Equal(t, "/v1/jobs/"+j.ID, res.Header.Get("Location"))
+
+	timer := time.After(5 * time.Second)
+	for j.Status != "done" {
+		select {
+		case <-timer:
+			t.Fatalf("job %s timed out with status %s", j.ID, j.Status)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		j = getJob(t, ts.URL+res.Header.Get("Location"))
+		require.NotEqual(t, "failed", j.Status)
+	}
+
+	require.NotNil(t, j.Result)
+	require.Equal(t, "article1", j.Result.Name)
+	require.Len(t, j.Result.Headings, 8)
+}
+
+func TestArticles_Tone(t *testing.T) {
+	ts := setup(addrClientM{})
+	defer ts.Close()
+
+	res, err := http.Post(ts.URL+"/v1/articles?tone=nightlife", "text/csv", strings.NewReader(articleCSV))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	got := articleResponse{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	require.Equal(t, "nightlife", got.Summary.Tone)
+	require.Equal(t, "Cinemas", got.Summary.PlaceOfInterest)
+
+	res, err = http.Post(ts.URL+"/v1/articles?tone=gloomy", "text/csv", strings.NewReader(articleCSV))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestArticles_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		addresses   client.Addresses
+		method      string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{
+			name:        "unsupported method",
+			addresses:   addrClientM{},
+			method:      http.MethodGet,
+			contentType: "application/json",
+			wantStatus:  http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "unsupported content type",
+			addresses:   addrClientM{},
+			method:      http.MethodPost,
+			contentType: "text/plain",
+			body:        articleCSV,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid json",
+			addresses:   addrClientM{},
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"photos": [`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "no photos",
+			addresses:   addrClientM{},
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"photos": []}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "no location data",
+			addresses:   failingAddrClientM{},
+			method:      http.MethodPost,
+			contentType: "text/csv",
+			body:        articleCSV,
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := setup(tt.addresses)
+			defer ts.Close()
+
+			req, err := http.NewRequest(tt.method, ts.URL+"/v1/articles", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tt.contentType)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tt.wantStatus, res.StatusCode)
+
+			got := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+			require.NotEmpty(t, got["error"])
+		})
+	}
+}
+
+func TestJob_NotFound(t *testing.T) {
+	ts := setup(addrClientM{})
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/v1/jobs/unknown")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestJobs_Retention(t *testing.T) {
+	ts := setup(addrClientM{}, server.WithJobRetention(time.Hour, 1))
+	defer ts.Close()
+
+	first := postJob(t, ts.URL)
+	require.Equal(t, "done", waitJob(t, ts.URL+first).Status)
+
+	// the finished job is evicted to keep at most one job.
+	second := postJob(t, ts.URL)
+	requireStatus(t, ts.URL+first, http.StatusNotFound)
+	require.Equal(t, "done", waitJob(t, ts.URL+second).Status)
+}
+
+func TestJobs_Timeout(t *testing.T) {
+	ts := setup(blockingAddrClientM{}, server.WithJobTimeout(100*time.Millisecond))
+	defer ts.Close()
+
+	j := waitJob(t, ts.URL+postJob(t, ts.URL))
+	require.Equal(t, "failed", j.Status)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	s := server.New(articleService(blockingAddrClientM{}))
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	loc := postJob(t, ts.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, s.Shutdown(ctx))
+	require.Equal(t, "failed", getJob(t, ts.URL+loc).Status)
+}
+
+func TestHealthAndReadiness(t *testing.T) {
+	as := service.ArticleService{}
+	s := server.New(as)
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	requireStatus(t, ts.URL+"/healthz", http.StatusOK)
+	requireStatus(t, ts.URL+"/readyz", http.StatusOK)
+
+	s.SetReady(false)
+	requireStatus(t, ts.URL+"/healthz", http.StatusOK)
+	requireStatus(t, ts.URL+"/readyz", http.StatusServiceUnavailable)
+}
+
+func setup(ac client.Addresses, opts ...server.Option) *httptest.Server {
+	return httptest.NewServer(server.New(articleService(ac), opts...).Handler())
+}
+
+func articleService(ac client.Addresses) service.ArticleService {
+	return service.ArticleService{
+		Clients: client.Clients{
+			Addresses: ac,
+			Weather:   weathClientM{},
+			POI:       poiClientM{},
+		},
+	}
+}
+
+func postJob(t *testing.T, url string) string {
+	res, err := http.Post(url+"/v1/articles?async=true&name=article1", "text/csv", strings.NewReader(articleCSV))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+	return res.Header.Get("Location")
+}
+
+// waitJob waits until the job is finished.
+func waitJob(t *testing.T, url string) jobResponse {
+	timer := time.After(5 * time.Second)
+	for {
+		j := getJob(t, url)
+		if j.Status == "done" || j.Status == "failed" {
+			return j
+		}
+
+		select {
+		case <-timer:
+			t.Fatalf("job %s timed out with status %s", j.ID, j.Status)
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
+
+func getJob(t *testing.T, url string) jobResponse {
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	j := jobResponse{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+	return j
+}
+
+func requireStatus(t *testing.T, url string, status int) {
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, status, res.StatusCode)
+}
+
+func (ac addrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{
+		Country: "Italy",
+		City:    "Sorrento",
+	}, nil
+}
+
+func (ac failingAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{}, errLocation
+}
+
+func (ac blockingAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	<-ctx.Done()
+	return photo.Location{}, ctx.Err()
+}
+
+func (wc weathClientM) Weather(ctx context.Context, pd photo.Data) (string, error) {
+	return "sunny", nil
+}
+
+func (pc poiClientM) PlacesOfInterest(ctx context.Context, pd photo.Data) (map[string]int, error) {
+	return map[string]int{
+		"Cinemas":     5,
+		"Restaurants": 10,
+		"Cafes":       15,
+	}, nil
+}
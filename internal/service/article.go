@@ -3,17 +3,88 @@ package service
 import (
 	"context"
 	"encoding/csv"
+	"io"
 	"log"
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/profile"
 )
 
 // Article ...
 type Article struct {
 	Name      string
 	PhotoData []photo.Data
-	Headings  []string
+	// Headings are in the order they were selected in, with the Scores they were selected by.
+	Headings []string
+	Scores   []ScoredHeading
+	// Profiled are the Headings fitted to each of the output profiles.
+	Profiled []profile.Heading
+	Summary  Summary
+}
+
+// Summary describes the additional photo information the article headings are based on.
+type Summary struct {
+	Country         string
+	City            string
+	Weather         string
+	Weekday         string
+	Month           string
+	Season          string
+	TimeOfDay       string
+	PlaceOfInterest string
+	// PlacesOfInterest are the most distinctive places of interest, the PlaceOfInterest first.
+	PlacesOfInterest []string
+	// Holiday is the public holiday or festival most photos were taken on, if any.
+	Holiday string
+	// Landmark is the named landmark recurring near the photos, if any, eg Colosseum.
+	Landmark string
+
+	// Itinerary lists the trip stop cities in the order they were visited.
+	Itinerary []string
+	// Region is the state or country shared by all the stops.
+	Region string
+	// Focus is the area of the dominant photo cluster, if any.
+	Focus *Area
+	// Duration classifies the trip length, eg weekend break, and Days is the number
+	// of calendar days the photos span.
+	Duration string
+	Days     int
+	// Tone is the editorial tone of the headings, if any.
+	Tone string
+	// Shares of the photos (weighted) with the above information.
+	Shares Shares
+
+	// number of photos and of successfully retrieved additional photo information.
+	Photos    int
+	Locations int
+	Weathers  int
+	Pois      int
+
+	Errors []string
+}
+
+// Shares (0-1) of the article photos with the summary information. The shares are weighted
+// by the photo weights and the places of interest share is that of all the places near the photos.
+type Shares struct {
+	Country         float64
+	City            float64
+	Weather         float64
+	Weekday         float64
+	Month           float64
+	Season          float64
+	TimeOfDay       float64
+	PlaceOfInterest float64
+	Holiday         float64
+	Landmark        float64
+}
+
+// Area is a circle around the centre (lat, lon) with the Spread (km) radius.
+type Area struct {
+	Latitude  float64
+	Longitude float64
+	Spread    float64
 }
 
 // ReadPhotoData ...
@@ -26,7 +97,12 @@ func ReadPhotoData(ctx context.Context, fp string) ([]photo.Data, error) {
 	}
 	defer f.Close()
 
-	r := csv.NewReader(f)
+	return ParsePhotoData(ctx, fp, f)
+}
+
+// ParsePhotoData reads article photo records (date, latitude, longitude) in CSV format.
+func ParsePhotoData(ctx context.Context, articleID string, rd io.Reader) ([]photo.Data, error) {
+	r := csv.NewReader(rd)
 	records, err := r.ReadAll()
 	if err != nil {
 		return nil, err
@@ -34,8 +110,11 @@ func ReadPhotoData(ctx context.Context, fp string) ([]photo.Data, error) {
 
 	photoD := []photo.Data{}
 	for i, r := range records {
+		if len(r) < 3 {
+			return nil, errors.Errorf("invalid photo record %d: expected date, latitude and longitude", i+1)
+		}
 		pd := photo.Data{
-			ArticleID: fp,
+			ArticleID: articleID,
 			ID:        i + 1,
 			Date:      r[0],
 			LatLon: photo.LatLon{
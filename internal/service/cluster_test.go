@@ -0,0 +1,166 @@
+// +build service_tests
+
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
+)
+
+// countingAddrClientM locates photos north of 40.7 in Naples and the rest in Sorrento.
+type countingAddrClientM struct {
+	calls *int32
+}
+
+// coastAddrClientM locates photos on the Amalfi Coast by longitude.
+type coastAddrClientM struct {
+	calls *int32
+}
+
+func TestProcessArticle_Clusters(t *testing.T) {
+	photoAt := func(id int, lat, lon float64) photo.Data {
+		return photo.Data{
+			ArticleID: "article1",
+			ID:        id,
+			Date:      "2019-10-27T13:27:58Z",
+			LatLon: photo.LatLon{
+				Latitude:  fmt.Sprintf("%f", lat),
+				Longitude: fmt.Sprintf("%f", lon),
+			},
+		}
+	}
+
+	// a burst of photos in one Naples spot, a walk around Sorrento and a stray photo.
+	photoL := []photo.Data{}
+	for i := 0; i < 10; i++ {
+		photoL = append(photoL, photoAt(len(photoL)+1, 40.852, 14.268))
+	}
+	for i := 0; i < 5; i++ {
+		photoL = append(photoL, photoAt(len(photoL)+1, 40.626+0.001*float64(i), 14.376))
+	}
+	photoL = append(photoL, photoAt(len(photoL)+1, 41.903, 12.496))
+
+	calls := int32(0)
+	as, _, _ := setup("article1")
+	as.Clients.Addresses = countingAddrClientM{calls: &calls}
+	as.Cluster = trip.DefaultClusterConfig
+
+	a, err := as.ProcessArticle(context.Background(), "article1", photoL)
+	require.NoError(t, err)
+
+	// one request per cluster and one for the stray photo.
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	require.Equal(t, 16, a.Summary.Locations)
+
+	// the burst does not outweigh the walk.
+	require.Equal(t, "Sorrento", a.Summary.City)
+	require.NotNil(t, a.Summary.Focus)
+	require.InDelta(t, 40.628, a.Summary.Focus.Latitude, 0.0001)
+	require.InDelta(t, 14.376, a.Summary.Focus.Longitude, 0.0001)
+	require.InDelta(t, 0.13, a.Summary.Focus.Spread, 0.01)
+}
+
+func TestProcessArticle_ClusteredStops(t *testing.T) {
+	photoAt := func(id int, date string, lat, lon float64) photo.Data {
+		return photo.Data{
+			ArticleID: "article1",
+			ID:        id,
+			Date:      date,
+			LatLon: photo.LatLon{
+				Latitude:  fmt.Sprintf("%f", lat),
+				Longitude: fmt.Sprintf("%f", lon),
+			},
+		}
+	}
+
+	// Sorrento, Positano and Amalfi, about 10km apart, with four photos each.
+	stops := []struct {
+		hour int
+		lon  float64
+	}{
+		{hour: 9, lon: 14.376},
+		{hour: 13, lon: 14.485},
+		{hour: 17, lon: 14.602},
+	}
+	photoL := []photo.Data{}
+	for _, s := range stops {
+		for i := 0; i < 4; i++ {
+			date := fmt.Sprintf("2019-10-27T%02d:%02d:00Z", s.hour, 10*i)
+			photoL = append(photoL, photoAt(len(photoL)+1, date, 40.63+0.001*float64(i), s.lon+0.001*float64(i)))
+		}
+	}
+
+	calls := int32(0)
+	as, _, _ := setup("article1")
+	as.Clients.Addresses = coastAddrClientM{calls: &calls}
+	// the stops are density-connected with the wide neighbourhood.
+	as.Cluster = trip.ClusterConfig{Eps: 15, MinPoints: 3}
+
+	a, err := as.ProcessArticle(context.Background(), "article1", photoL)
+	require.NoError(t, err)
+
+	// one request per stop.
+	require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	require.Equal(t, 12, a.Summary.Locations)
+	require.Equal(t, []string{"Sorrento", "Positano", "Amalfi"}, a.Summary.Itinerary)
+	require.NotNil(t, a.Summary.Focus)
+	require.Less(t, a.Summary.Focus.Spread, 1.0)
+}
+
+func TestProcessArticle_NoClusters(t *testing.T) {
+	photoL := []photo.Data{
+		{ArticleID: "article1", ID: 1, Date: "2019-10-27T13:27:58Z",
+			LatLon: photo.LatLon{Latitude: "40.852", Longitude: "14.268"}},
+		{ArticleID: "article1", ID: 2, Date: "2019-10-27T13:27:58Z",
+			LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+	}
+
+	calls := int32(0)
+	as, _, _ := setup("article1")
+	as.Clients.Addresses = countingAddrClientM{calls: &calls}
+
+	a, err := as.ProcessArticle(context.Background(), "article1", photoL)
+	require.NoError(t, err)
+
+	// every photo is located.
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	require.Equal(t, 2, a.Summary.Locations)
+	require.Nil(t, a.Summary.Focus)
+}
+
+func (ac countingAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	atomic.AddInt32(ac.calls, 1)
+
+	lat, err := strconv.ParseFloat(pd.LatLon.Latitude, 64)
+	if err != nil {
+		return photo.Location{}, err
+	}
+	if lat > 40.7 {
+		return photo.Location{Country: "Italy", State: "Campania", City: "Naples"}, nil
+	}
+	return photo.Location{Country: "Italy", State: "Campania", City: "Sorrento"}, nil
+}
+
+func (ac coastAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	atomic.AddInt32(ac.calls, 1)
+
+	lon, err := strconv.ParseFloat(pd.LatLon.Longitude, 64)
+	if err != nil {
+		return photo.Location{}, err
+	}
+	l := photo.Location{Country: "Italy", State: "Campania", City: "Amalfi"}
+	switch {
+	case lon < 14.43:
+		l.City = "Sorrento"
+	case lon < 14.54:
+		l.City = "Positano"
+	}
+	return l, nil
+}
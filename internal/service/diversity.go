@@ -0,0 +1,113 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// Diversity determines the selection of diverse headings from the oversampled heading candidates.
+type Diversity struct {
+	// Oversample is the number of candidates created from each template.
+	Oversample int
+	// Lambda (0-1) trades the candidate score (1) off against the dissimilarity (0)
+	// to the headings already selected (maximal marginal relevance).
+	Lambda float64
+}
+
+// DefaultDiversity is used if no diversity is provided.
+var DefaultDiversity = Diversity{Oversample: 4, Lambda: 0.7}
+
+// phraseLength is the number of words of the phrases remembered by the PhraseBook.
+const phraseLength = 3
+
+// PhraseBook remembers the phrases of the headings suggested in one run, so that
+// the headings of different articles do not reuse the same phrasing. A nil PhraseBook
+// remembers nothing.
+type PhraseBook struct {
+	mu   sync.Mutex
+	used map[string]bool
+}
+
+// NewPhraseBook is a PhraseBook constructor.
+func NewPhraseBook() *PhraseBook {
+	return &PhraseBook{used: map[string]bool{}}
+}
+
+// Reuse is the share (0-1) of the heading phrases used by the headings already suggested.
+func (pb *PhraseBook) Reuse(heading string) float64 {
+	if pb == nil {
+		return 0
+	}
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	ps := phrases(heading)
+	if len(ps) == 0 {
+		return 0
+	}
+	reused := 0
+	for _, p := range ps {
+		if pb.used[p] {
+			reused++
+		}
+	}
+	return float64(reused) / float64(len(ps))
+}
+
+// Add remembers the phrases of the suggested headings.
+func (pb *PhraseBook) Add(headings ...string) {
+	if pb == nil {
+		return
+	}
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+
+	for _, h := range headings {
+		for _, p := range phrases(h) {
+			pb.used[p] = true
+		}
+	}
+}
+
+// SelectDiverse selects up to n of the scored headings with maximal marginal relevance: the next
+// heading selected has the best score, lowered by its similarity to the headings already selected
+// or by the reuse of the phrases remembered by the phrase book. The headings are selected
+// in the order of their scores if lambda is 1.
+func SelectDiverse(scored []ScoredHeading, n int, lambda float64, pb *PhraseBook) []ScoredHeading {
+	candidates := append([]ScoredHeading{}, scored...)
+	reuse := make([]float64, len(candidates))
+	for i, c := range candidates {
+		reuse[i] = pb.Reuse(c.Heading)
+	}
+
+	selected := []ScoredHeading{}
+	for len(selected) < n && len(candidates) > 0 {
+		best, bestMMR := 0, math.Inf(-1)
+		for i, c := range candidates {
+			overlap := reuse[i]
+			for _, s := range selected {
+				overlap = math.Max(overlap, similarity(c.Heading, s.Heading))
+			}
+			if mmr := lambda*c.Score - (1-lambda)*overlap; mmr > bestMMR {
+				best, bestMMR = i, mmr
+			}
+		}
+
+		selected = append(selected, candidates[best])
+		candidates = append(candidates[:best], candidates[best+1:]...)
+		reuse = append(reuse[:best], reuse[best+1:]...)
+	}
+
+	return selected
+}
+
+// phrases of the heading are its lower case word trigrams.
+func phrases(heading string) []string {
+	words := strings.Fields(strings.ToLower(heading))
+	ps := []string{}
+	for i := 0; i+phraseLength <= len(words); i++ {
+		ps = append(ps, strings.Join(words[i:i+phraseLength], " "))
+	}
+	return ps
+}
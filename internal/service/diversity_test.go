@@ -0,0 +1,138 @@
+// +build unit_tests
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+func TestSelectDiverse(t *testing.T) {
+	scored := []service.ScoredHeading{
+		{Heading: "Glorious Weekend stay in Sorrento packed with Bars", Score: 0.9},
+		{Heading: "Brilliant Weekend stay in Sorrento packed with Bars", Score: 0.85},
+		{Heading: "Sunny October evenings on the Amalfi Coast", Score: 0.7},
+	}
+
+	tests := []struct {
+		name   string
+		n      int
+		lambda float64
+		used   []string
+		want   service.ArticleHeadings
+	}{
+		{
+			name:   "near-duplicate is not selected",
+			n:      2,
+			lambda: 0.7,
+			want: service.ArticleHeadings{
+				"Glorious Weekend stay in Sorrento packed with Bars",
+				"Sunny October evenings on the Amalfi Coast",
+			},
+		},
+		{
+			name:   "by score only",
+			n:      2,
+			lambda: 1,
+			want: service.ArticleHeadings{
+				"Glorious Weekend stay in Sorrento packed with Bars",
+				"Brilliant Weekend stay in Sorrento packed with Bars",
+			},
+		},
+		{
+			name:   "phrasing used by another article is avoided",
+			n:      1,
+			lambda: 0.7,
+			used:   []string{"Glorious Weekend stay in Sorrento packed with cocktails"},
+			want: service.ArticleHeadings{
+				"Sunny October evenings on the Amalfi Coast",
+			},
+		},
+		{
+			name:   "fewer candidates",
+			n:      5,
+			lambda: 0.7,
+			want: service.ArticleHeadings{
+				"Glorious Weekend stay in Sorrento packed with Bars",
+				"Sunny October evenings on the Amalfi Coast",
+				"Brilliant Weekend stay in Sorrento packed with Bars",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pb *service.PhraseBook
+			if tt.used != nil {
+				pb = service.NewPhraseBook()
+				pb.Add(tt.used...)
+			}
+			require.Equal(t, tt.want, service.HeadingsOf(service.SelectDiverse(scored, tt.n, tt.lambda, pb)))
+		})
+	}
+}
+
+func TestPhraseBook(t *testing.T) {
+	var nilBook *service.PhraseBook
+	nilBook.Add("Sunny days in Sorrento")
+	require.Equal(t, 0.0, nilBook.Reuse("Sunny days in Sorrento"))
+
+	pb := service.NewPhraseBook()
+	require.Equal(t, 0.0, pb.Reuse("Sunny days in Sorrento"))
+
+	pb.Add("Sunny days in Sorrento")
+	require.Equal(t, 1.0, pb.Reuse("sunny days in Sorrento"))
+	require.Equal(t, 2.0/3, pb.Reuse("Sunny days in Sorrento again"))
+	require.Equal(t, 0.0, pb.Reuse("Rainy days in Rome"))
+	require.Equal(t, 0.0, pb.Reuse("Sorrento"))
+}
+
+func TestHeadingGenerator_Suggest(t *testing.T) {
+	data := service.NewHeadingData(nil, nil, nil)
+	data.Country, data.City, data.Weather = "Italy", "Sorrento", "sunny"
+	data.Weekday, data.Month, data.Season, data.PlaceOfInterest = "Weekend", "October", "Autumn", "Bars"
+	shares := service.Shares{Country: 1, City: 1, Weather: 1, Weekday: 1, Month: 1, Season: 1, PlaceOfInterest: 1}
+
+	hg, err := service.NewHeadingGenerator(nil, 1)
+	require.NoError(t, err)
+
+	once, err := hg.Candidates(data, 1)
+	require.NoError(t, err)
+	oversampled, err := hg.Candidates(data, 4)
+	require.NoError(t, err)
+	require.Greater(t, len(oversampled), len(once))
+	seen := map[string]bool{}
+	for _, h := range oversampled {
+		require.False(t, seen[h], "duplicate candidate %q", h)
+		seen[h] = true
+	}
+
+	pb := service.NewPhraseBook()
+	first, err := hg.Suggest(data, shares, service.ScoreWeights{}, service.Diversity{}, 0, 0, pb)
+	require.NoError(t, err)
+	require.Len(t, first, len(service.DefaultTemplates))
+
+	// the headings keep the scores they were selected by, the best scored first.
+	top, err := hg.Suggest(data, shares, service.ScoreWeights{}, service.Diversity{}, 3, 0.5, nil)
+	require.NoError(t, err)
+	require.Len(t, top, 3)
+	for _, sh := range top {
+		require.GreaterOrEqual(t, sh.Score, 0.5)
+		require.LessOrEqual(t, sh.Score, top[0].Score)
+	}
+
+	// another article of the batch does not get the same headings.
+	second, err := hg.Suggest(data, shares, service.ScoreWeights{}, service.Diversity{}, 0, 0, pb)
+	require.NoError(t, err)
+	repeated := 0
+	for _, h := range service.HeadingsOf(second) {
+		for _, f := range service.HeadingsOf(first) {
+			if h == f {
+				repeated++
+			}
+		}
+	}
+	require.Less(t, repeated, len(second)/2)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+)
+
+// Additional photo information kinds.
+const (
+	LocationInfo = "location"
+	WeatherInfo  = "weather"
+	PoiInfo      = "places of interest"
+)
+
+// PhotoError is a failure to retrieve additional information for a photo.
+// The provider error is kept, so it can be inspected with errors.Is/As.
+type PhotoError struct {
+	ArticleID string
+	PhotoID   int
+	Info      string
+	Err       error
+}
+
+func (e *PhotoError) Error() string {
+	return fmt.Sprintf("failure to retrieve %s data for photo %d of article %s: %s",
+		e.Info, e.PhotoID, e.ArticleID, e.Err)
+}
+
+func (e *PhotoError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorAction is how the service handles a failure to retrieve additional photo information.
+type ErrorAction int
+
+const (
+	// SkipPhoto continues without the photo information (eg location not found, invalid response).
+	SkipPhoto ErrorAction = iota
+	// Retry repeats the request (eg rate limiting, provider temporarily unavailable).
+	Retry
+	// Abort stops processing of the article (eg invalid API key), as no other request can succeed.
+	Abort
+)
+
+// ClassifyError decides how to handle a provider failure of a request made with the context.
+// Requests that timed out are retried while the context is not done.
+func ClassifyError(ctx context.Context, err error) ErrorAction {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		return Retry
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return SkipPhoto
+	case errors.Is(err, client.ErrUnauthorized):
+		return Abort
+	case errors.Is(err, client.ErrRateLimited) || errors.Is(err, client.ErrProviderUnavailable):
+		return Retry
+	default:
+		return SkipPhoto
+	}
+}
+
+// RetryPolicy determines how failed requests are retried.
+type RetryPolicy struct {
+	// Attempts is the maximum number of requests made for a photo.
+	Attempts int
+	// Backoff is the delay before the first retry. It doubles with each retry.
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy is used when no RetryPolicy is provided.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts: 3,
+	Backoff:  500 * time.Millisecond,
+}
+
+// withRetry calls fn until it succeeds, fails with an error that is not retryable
+// or the attempts are exhausted.
+func (rp RetryPolicy) withRetry(ctx context.Context, fn func() error) error {
+	if rp.Attempts <= 0 {
+		rp = DefaultRetryPolicy
+	}
+
+	backoff := rp.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= rp.Attempts || ClassifyError(ctx, err) != Retry {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,179 @@
+// +build service_tests
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+// flakyAddrClientM fails with the provided error for the first failures requests.
+type flakyAddrClientM struct {
+	err      error
+	failures int32
+	calls    *int32
+}
+
+func TestClassifyError(t *testing.T) {
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want service.ErrorAction
+	}{
+		{
+			name: "rate limited",
+			err:  &client.ProviderError{Kind: client.ErrRateLimited},
+			want: service.Retry,
+		},
+		{
+			name: "provider unavailable",
+			err:  &client.ProviderError{Kind: client.ErrProviderUnavailable},
+			want: service.Retry,
+		},
+		{
+			name: "unauthorized",
+			err:  &service.PhotoError{Err: &client.ProviderError{Kind: client.ErrUnauthorized}},
+			want: service.Abort,
+		},
+		{
+			name: "not found",
+			err:  &client.ProviderError{Kind: client.ErrNotFound},
+			want: service.SkipPhoto,
+		},
+		{
+			name: "decode",
+			err:  &client.ProviderError{Kind: client.ErrDecode},
+			want: service.SkipPhoto,
+		},
+		{
+			name: "cancelled",
+			err:  &client.ProviderError{Kind: client.ErrProviderUnavailable, Err: context.Canceled},
+			want: service.SkipPhoto,
+		},
+		{
+			name: "request timed out",
+			err:  &service.PhotoError{Err: fmt.Errorf("request: %w", context.DeadlineExceeded)},
+			want: service.Retry,
+		},
+		{
+			name: "request timed out with the context done",
+			ctx:  done,
+			err:  &service.PhotoError{Err: fmt.Errorf("request: %w", context.DeadlineExceeded)},
+			want: service.SkipPhoto,
+		},
+		{
+			name: "other",
+			err:  errors.New("other"),
+			want: service.SkipPhoto,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			require.Equal(t, tt.want, service.ClassifyError(ctx, tt.err))
+		})
+	}
+}
+
+func TestProcessArticle_ErrorHandling(t *testing.T) {
+	photoL := []photo.Data{
+		{ArticleID: "article1", ID: 1, Date: "2019-11-25T22:37:44Z"},
+		{ArticleID: "article1", ID: 2, Date: "2019-11-25T22:37:44Z"},
+	}
+	tests := []struct {
+		name      string
+		err       error
+		failures  int32
+		wantCalls int32
+		wantErr   error
+		wantLocs  int
+	}{
+		{
+			name:      "retried until success",
+			err:       &client.ProviderError{Kind: client.ErrRateLimited},
+			failures:  2,
+			wantCalls: 4,
+			wantLocs:  2,
+		},
+		{
+			name:      "timed out request retried",
+			err:       fmt.Errorf("request: %w", context.DeadlineExceeded),
+			failures:  2,
+			wantCalls: 4,
+			wantLocs:  2,
+		},
+		{
+			name:      "retries exhausted",
+			err:       &client.ProviderError{Kind: client.ErrProviderUnavailable},
+			failures:  100,
+			wantCalls: 6,
+			wantErr:   service.ErrNoAdditionalInfo,
+		},
+		{
+			name:      "photo skipped",
+			err:       &client.ProviderError{Kind: client.ErrNotFound},
+			failures:  1,
+			wantCalls: 2,
+			wantLocs:  1,
+		},
+		{
+			name:      "processing aborted",
+			err:       &client.ProviderError{Kind: client.ErrUnauthorized},
+			failures:  100,
+			wantCalls: 1,
+			wantErr:   client.ErrUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := int32(0)
+			as, _, _ := setup("article1")
+			as.Clients.Addresses = flakyAddrClientM{err: tt.err, failures: tt.failures, calls: &calls}
+			as.Retry = service.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+			// photos are processed one at a time to make the number of calls deterministic.
+			locations := 0
+			var err error
+			for i := range photoL {
+				var a service.Article
+				a, err = as.ProcessArticle(context.Background(), "article1", photoL[i:i+1])
+				locations += a.Summary.Locations
+				if service.ClassifyError(context.Background(), err) == service.Abort {
+					break
+				}
+			}
+
+			require.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+			require.Equal(t, tt.wantLocs, locations)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+			}
+		})
+	}
+}
+
+func (ac flakyAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	if atomic.AddInt32(ac.calls, 1) <= ac.failures {
+		return photo.Location{}, ac.err
+	}
+	return photo.Location{
+		Country: "Czechia",
+		City:    "Prague",
+	}, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+// Event carries one piece of additional photo information, or a failure to retrieve it,
+// as soon as it is collected. Only one of Location, Weather, Poi or Error is set.
+type Event struct {
+	Article string
+
+	Location *photo.LocationM
+	Weather  *photo.WeatherM
+	Poi      *photo.PoiM
+
+	Error error
+}
+
+// sequential makes sure notify is not called concurrently.
+func sequential(notify func(Event)) func(Event) {
+	if notify == nil {
+		return func(Event) {}
+	}
+
+	mu := &sync.Mutex{}
+	return func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		notify(e)
+	}
+}
+
+// relayWithEvents provides a channel for the clients to send the additional photo information to.
+// The information is reported and relayed to the provided channel, which is closed once
+// the clients are done.
+func relayWithEvents(chans photo.Channel, notify func(Event)) photo.Channel {
+	collectCh := photo.Channel{
+		Article: chans.Article,
+
+		Location: make(chan photo.LocationM),
+		Weather:  make(chan photo.WeatherM),
+		Poi:      make(chan photo.PoiM),
+
+		Cancel: chans.Cancel,
+		Error:  make(chan error),
+	}
+
+	go func() {
+		defer close(chans.Location)
+
+		for m := range collectCh.Location {
+			m := m
+			notify(Event{Article: chans.Article, Location: &m})
+			chans.Location <- m
+		}
+	}()
+
+	go func() {
+		defer close(chans.Weather)
+
+		for m := range collectCh.Weather {
+			m := m
+			notify(Event{Article: chans.Article, Weather: &m})
+			chans.Weather <- m
+		}
+	}()
+
+	go func() {
+		defer close(chans.Poi)
+
+		for m := range collectCh.Poi {
+			m := m
+			notify(Event{Article: chans.Article, Poi: &m})
+			chans.Poi <- m
+		}
+	}()
+
+	return collectCh
+}
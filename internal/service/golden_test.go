@@ -0,0 +1,155 @@
+// +build unit_tests
+
+package service_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+// Refresh the golden headings after changing the templates or the catalogs:
+//	go test -tags unit_tests ./internal/service -run Golden -update
+var update = flag.Bool("update", false, "update the golden headings")
+
+func TestGenerate_Golden(t *testing.T) {
+	// the place names are provided in the language of the locale.
+	places := map[string]map[string]string{
+		"de": {"Italy": "Italien", "Campania": "Kampanien", "Naples": "Neapel", "Rome": "Rom", "Colosseum": "Kolosseum"},
+		"it": {"Italy": "Italia", "Naples": "Napoli", "Rome": "Roma", "Colosseum": "Colosseo"},
+		"es": {"Italy": "Italia", "Naples": "Nápoles", "Rome": "Roma", "Colosseum": "Coliseo"},
+	}
+
+	vocabulary := service.NewHeadingData(nil, nil, nil)
+	scenario := func(f func(*service.HeadingData)) service.HeadingData {
+		data := vocabulary
+		data.Country = "Italy"
+		data.City = "Sorrento"
+		data.Weather = "sunny"
+		data.Weekday = "Weekend"
+		data.Month = "August"
+		data.Season = "Summer"
+		data.TimeOfDay = "evening"
+		data.PlaceOfInterest = "Restaurants"
+		data.Duration = "weekend break"
+		data.Days = 2
+		f(&data)
+		return data
+	}
+
+	scenarios := []struct {
+		name string
+		data service.HeadingData
+	}{
+		{
+			name: "single stop",
+			data: scenario(func(*service.HeadingData) {}),
+		},
+		{
+			name: "route",
+			data: scenario(func(d *service.HeadingData) {
+				d.Itinerary = []string{"Naples", "Pompeii", "Sorrento", "Amalfi"}
+				d.Region = "Campania"
+				d.Duration = "week-long holiday"
+				d.Days = 7
+			}),
+		},
+		{
+			name: "holiday",
+			data: scenario(func(d *service.HeadingData) {
+				d.Holiday = "Ferragosto"
+				d.Weekday = "Monday"
+				d.Duration = "day trip"
+				d.Days = 1
+			}),
+		},
+		{
+			name: "mostly rainy",
+			data: scenario(func(d *service.HeadingData) {
+				d.Weather = "mostly rainy"
+				d.Month = "November"
+				d.Season = "Autumn"
+				d.PlaceOfInterest = "Museums"
+				d.Duration = ""
+			}),
+		},
+		{
+			name: "distinctive places",
+			data: scenario(func(d *service.HeadingData) {
+				d.PlaceOfInterest = "Museums"
+				d.PlacesOfInterest = []string{"Museums", "Botanical Gardens"}
+			}),
+		},
+		{
+			name: "three distinctive places",
+			data: scenario(func(d *service.HeadingData) {
+				d.Holiday = "Ferragosto"
+				d.PlaceOfInterest = "Theatres"
+				d.PlacesOfInterest = []string{"Theatres", "Museums", "Swimming Pools"}
+			}),
+		},
+		{
+			name: "landmark",
+			data: scenario(func(d *service.HeadingData) {
+				d.Landmark = "Piazza Tasso"
+				d.Duration = "long weekend"
+				d.Days = 3
+			}),
+		},
+		{
+			name: "landmark with an article",
+			data: scenario(func(d *service.HeadingData) {
+				d.City = "Rome"
+				d.Landmark = "Colosseum"
+				d.Weekday = "Sunday"
+			}),
+		},
+	}
+
+	for _, l := range locale.Locales() {
+		t.Run(l, func(t *testing.T) {
+			c, err := locale.Load(l)
+			require.NoError(t, err)
+
+			name := func(place string) string {
+				if n, ok := places[l][place]; ok {
+					return n
+				}
+				return place
+			}
+
+			b := &strings.Builder{}
+			for _, s := range scenarios {
+				data := s.data
+				data.Country, data.Region = name(data.Country), name(data.Region)
+				data.City, data.Landmark = name(data.City), name(data.Landmark)
+				data.Itinerary = nil
+				for _, city := range s.data.Itinerary {
+					data.Itinerary = append(data.Itinerary, name(city))
+				}
+
+				hg, err := service.NewLocalizedHeadingGenerator(c, nil, 1)
+				require.NoError(t, err)
+				headings, err := hg.Generate(data)
+				require.NoError(t, err)
+
+				fmt.Fprintf(b, "# %s\n%s\n\n", s.name, strings.Join(headings, "\n"))
+			}
+
+			path := filepath.Join("testdata", "golden", l+".golden")
+			if *update {
+				require.NoError(t, ioutil.WriteFile(path, []byte(b.String()), 0o600))
+			}
+			golden, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, string(golden), b.String())
+		})
+	}
+}
@@ -3,21 +3,20 @@ package service
 import (
 	"context"
 	"fmt"
-	"log"
 	"math/rand"
-	"sort"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
+	"github.com/tamarakaufler/travel-article-headings/internal/nlg"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/profile"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
 )
 
-type SortPositionList []SortPosition
-type SortPosition struct {
-	name  string
-	count int
-}
-
 // GatherLocationInfo gathers photo info for each article.
 func GatherLocationInfo(ctx context.Context, chans photo.Channel,
 ) []photo.LocationM {
@@ -69,271 +68,442 @@ func GatherPoiInfo(ctx context.Context, chans photo.Channel,
 	}
 }
 
-//nolint:funlen
-// CreateArticleHeadings - this is where the fun magic happens.
-// The function processes one article.
-func CreateArticleHeadings(ctx context.Context,
-	articleLocationData []photo.LocationM, articleWeatherData []photo.WeatherM, articlePOIData []photo.PoiM,
-) ArticleHeadings {
-	headingStart1 := []string{"Enjoy your break", "Having great time",
-		"Experience of a lifetime", "Have a holiday of a lifetime", "Wonderful break"}
-	headingStart2 := []string{"Enjoy happy days", "Have hilarious time"}
-	headingMiddle := []string{"with friends", "with family", "on your own"}
-	headingForPlaces := []string{"full of", "bursting with", "brimming with", "packed with"}
-	adjectives := []string{"Hilarious", "Beautiful", "Brilliant", "Family fun", "Glorious"}
-
-	country, city, errLoc := GetTopLocation(articleLocationData)
-	if errLoc != nil {
-		city = "city"
-		country = "country"
-	}
-	weather := GetTopWeather(articleWeatherData)
-	weekday, month, season := GetTopTimeInfo(articleWeatherData)
-	if weekday == "Saturday" || weekday == "Sunday" {
-		weekday = "Weekend"
-	}
+// DefaultTemplates are the article heading templates. The templates are executed with HeadingData
+// and can pick a random phrase from the HeadingData vocabulary using the pick function.
+// Route headings are used for trips with several stops.
+var DefaultTemplates = []string{
+	`{{pick .Starts}} {{pick .Company}} {{locative .Weather .Country}}`,
+	`{{pick .Adjectives}} {{.Month}} {{pick .Company}} {{locative .Weather .Country}}`,
+	`{{pick .Adjectives}} {{common .Weekday}} {{if .Landmark}}by {{landmark .Landmark}} in {{.City}}` +
+		`{{else}}enjoying the {{places .PlaceOfInterest}} of {{.City}}{{end}}`,
+	`{{pick .Adjectives}} {{if .Holiday}}{{.Holiday}}{{else}}{{common .Season}}{{end}} break ` +
+		`{{locative "" .Country}} {{pick .ForPlaces}} {{placelist .Categories}}`,
+	`{{if .Route}}{{number (len .Itinerary)}} {{count (len .Itinerary) "city"}}{{if .Region}} of {{the .Region}}{{end}} ` +
+		`{{pick .ForPlaces}} {{places .PlaceOfInterest}}{{else}}{{pick .Adjectives}} ` +
+		`{{if .Duration}}{{.Duration}}{{else}}{{common .Weekday}} stay{{end}} ` +
+		`{{locative "" .City}} {{pick .ForPlaces}} {{places .PlaceOfInterest}}{{end}}`,
+	`{{pick .HappyStarts}} {{pick .Company}} {{locative .Weather .City}}`,
+	`{{pick .Starts}} {{pick .Company}} {{locative "" .Country}} {{pick .ForPlaces}} {{places .PlaceOfInterest}}`,
+	`{{if .Route}}From {{.From}} to {{.To}}{{else if .Holiday}}{{.Holiday}} {{locative "" .City}}` +
+		`{{else}}{{pick .Starts}} {{locative "" .City}}{{end}} {{pick .ForPlaces}} {{placelist .Categories}}`,
+}
 
-	poi := GetTopPlaceOfInterest(articlePOIData)
+// mostly qualifies the weather, that is not dominant.
+const mostly = "mostly "
 
-	headings := []string{}
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(headingStart1))
-		kk := rand.Intn(len(headingMiddle))
+// fallback location of the headings, if the photo locations are not known.
+const (
+	unknownCity    = "city"
+	unknownCountry = "country"
+)
 
-		s := headingStart1[jj]
-		m := headingMiddle[kk]
-		heading := strings.Join([]string{s, m, "in", weather, country}, " ")
-		headings = append(headings, heading)
-	}
+// HeadingData holds the article information the headings are created from.
+type HeadingData struct {
+	Country         string
+	City            string
+	Weather         string
+	Weekday         string
+	Month           string
+	Season          string
+	TimeOfDay       string
+	PlaceOfInterest string
+	// PlacesOfInterest are the most distinctive places of interest, the PlaceOfInterest first.
+	PlacesOfInterest []string
+	// Holiday is the public holiday or festival most photos were taken on, if any.
+	Holiday string
+	// Landmark is the named landmark recurring near the photos, if any, eg Colosseum.
+	Landmark string
+
+	// Itinerary lists the trip stop cities in the order they were visited.
+	Itinerary []string
+	// Region is the state or country shared by all the stops.
+	Region string
+	// Duration classifies the trip length, eg weekend break, and Days is the number
+	// of calendar days the photos span.
+	Duration string
+	Days     int
+
+	// Tone is the editorial tone of the headings, if any. Its vocabulary replaces the default one
+	// and the templates using the fields it avoids are not used.
+	Tone tone.Tone
+
+	// vocabulary
+	Starts      []string
+	HappyStarts []string
+	Company     []string
+	ForPlaces   []string
+	Adjectives  []string
+}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(adjectives))
-		kk := rand.Intn(len(headingMiddle))
+// Route is true for trips with several stops.
+func (hd HeadingData) Route() bool {
+	return len(hd.Itinerary) > 1
+}
 
-		s := adjectives[jj]
-		m := headingMiddle[kk]
-		heading := strings.Join([]string{s, month, m, "in", weather, country}, " ")
-		headings = append(headings, heading)
+// From is the first stop of the trip.
+func (hd HeadingData) From() string {
+	if len(hd.Itinerary) == 0 {
+		return hd.City
 	}
+	return hd.Itinerary[0]
+}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(adjectives))
-		mm := rand.Intn(len(headingForPlaces))
-		p := headingForPlaces[mm]
+// To is the last stop of the trip.
+func (hd HeadingData) To() string {
+	if len(hd.Itinerary) == 0 {
+		return hd.City
+	}
+	return hd.Itinerary[len(hd.Itinerary)-1]
+}
 
-		s := adjectives[jj]
-		heading := strings.Join([]string{s, weekday, "enjoying", city, "of", p, poi}, " ")
-		headings = append(headings, heading)
+// Categories lists the places of interest of the headings: the PlacesOfInterest, or the PlaceOfInterest
+// if there are none.
+func (hd HeadingData) Categories() []string {
+	if len(hd.PlacesOfInterest) > 0 {
+		return hd.PlacesOfInterest
+	}
+	if hd.PlaceOfInterest == "" {
+		return nil
 	}
+	return []string{hd.PlaceOfInterest}
+}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(adjectives))
-		mm := rand.Intn(len(headingForPlaces))
-		p := headingForPlaces[mm]
+// WithTone provides the heading data in the tone: with the tone vocabulary, if provided.
+// The vocabulary of the locale catalogs still replaces the tone vocabulary.
+func (hd HeadingData) WithTone(t tone.Tone) HeadingData {
+	hd.Tone = t
+
+	vocabulary := []struct {
+		phrases *[]string
+		tone    []string
+	}{
+		{&hd.Starts, t.Starts},
+		{&hd.HappyStarts, t.HappyStarts},
+		{&hd.Company, t.Company},
+		{&hd.ForPlaces, t.ForPlaces},
+		{&hd.Adjectives, t.Adjectives},
+	}
+	for _, v := range vocabulary {
+		if len(v.tone) > 0 {
+			*v.phrases = v.tone
+		}
+	}
+
+	return hd
+}
 
-		s := adjectives[jj]
-		heading := strings.Join([]string{s, season, "break in", country, p, poi}, " ")
-		headings = append(headings, heading)
+// HeadingGenerator creates article headings from templates.
+type HeadingGenerator struct {
+	templates []*template.Template
+	sources   []string
+	catalog   *locale.Catalog
+
+	mu  *sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewHeadingGenerator is a HeadingGenerator constructor. The seed determines the random
+// choice of the template vocabulary.
+func NewHeadingGenerator(templates []string, seed int64) (*HeadingGenerator, error) {
+	return NewLocalizedHeadingGenerator(locale.Default(), templates, seed)
+}
+
+// NewLocalizedHeadingGenerator creates headings in the language of the catalog. The catalog
+// templates (or the DefaultTemplates if the catalog has none) are used if no templates are provided.
+// Besides pick and number, the templates can use the catalog grammar hooks: article, in,
+// near (eg {{near .Landmark}} {{.Landmark}}: am Kolosseum, vicino a Piazza Navona), inflect (eg {{inflect "dative" .Weather .Country}}), list (eg {{list .Categories}}: Museen und Theatern)
+// and capitalize, and the English grammar:
+// the (the USA), landmark (the Colosseum, but Piazza Navona), locative (eg {{locative .Weather .Country}}:
+// in the sunny USA, on Capri), places (plural lower case places of interest), placelist
+// (eg {{placelist .Categories}}: museums and botanical gardens), count (eg {{count 2 "city"}}: cities)
+// and common (weekend, summer, but Sunday).
+func NewLocalizedHeadingGenerator(c *locale.Catalog, templates []string, seed int64) (*HeadingGenerator, error) {
+	if len(templates) == 0 {
+		templates = c.Templates
+	}
+	if len(templates) == 0 {
+		templates = DefaultTemplates
 	}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(adjectives))
-		mm := rand.Intn(len(headingForPlaces))
-		p := headingForPlaces[mm]
+	hg := &HeadingGenerator{
+		catalog: c,
+		mu:      &sync.Mutex{},
+		rnd:     rand.New(rand.NewSource(seed)), //nolint:gosec
+	}
 
-		s := adjectives[jj]
-		heading := strings.Join([]string{s, weekday, "stay in", city, p, poi}, " ")
-		headings = append(headings, heading)
+	funcs := template.FuncMap{
+		"pick":       hg.pick,
+		"number":     c.Number,
+		"article":    c.Article,
+		"in":         c.In,
+		"near":       c.Near,
+		"inflect":    c.Inflect,
+		"list":       c.List,
+		"capitalize": locale.Capitalize,
+		// English grammar
+		"the":       nlg.Article,
+		"landmark":  nlg.Landmark,
+		"locative":  nlg.Locative,
+		"places":    nlg.Places,
+		"placelist": nlg.PlaceList,
+		"count":     nlg.Count,
+		"common":    nlg.Common,
+	}
+	for i, t := range templates {
+		tmpl, err := template.New(fmt.Sprintf("heading%d", i+1)).Funcs(funcs).Parse(t)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid heading template %q", t)
+		}
+		hg.templates = append(hg.templates, tmpl)
+		hg.sources = append(hg.sources, t)
 	}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(headingStart2))
-		kk := rand.Intn(len(headingMiddle))
+	return hg, nil
+}
 
-		s := headingStart2[jj]
-		m := headingMiddle[kk]
-		heading := strings.Join([]string{s, m, "in", weather, city}, " ")
-		headings = append(headings, heading)
+func defaultHeadingGenerator() *HeadingGenerator {
+	hg, err := NewHeadingGenerator(DefaultTemplates, time.Now().UnixNano())
+	if err != nil {
+		panic(err)
 	}
+	return hg
+}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(headingStart1))
-		kk := rand.Intn(len(headingMiddle))
-		mm := rand.Intn(len(headingForPlaces))
+// Generate executes the heading templates.
+func (hg *HeadingGenerator) Generate(data HeadingData) (ArticleHeadings, error) {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
 
-		s := headingStart1[jj]
-		m := headingMiddle[kk]
-		p := headingForPlaces[mm]
-		heading := strings.Join([]string{s, m, "in", country, p, poi}, " ")
-		headings = append(headings, heading)
+	return hg.generate(hg.localize(data))
+}
+
+// Candidates executes the heading templates n times (at least once), each time with another random choice
+// of the template vocabulary. Duplicate headings are left out.
+func (hg *HeadingGenerator) Candidates(data HeadingData, n int) (ArticleHeadings, error) {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+
+	data = hg.localize(data)
+
+	seen := map[string]bool{}
+	candidates := ArticleHeadings{}
+	for i := 0; i < n || i == 0; i++ {
+		headings, err := hg.generate(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range headings {
+			if !seen[h] {
+				seen[h] = true
+				candidates = append(candidates, h)
+			}
+		}
 	}
 
-	for i := 0; i < 1; i++ {
-		rand.Seed(time.Now().UnixNano())
-		jj := rand.Intn(len(headingStart1))
-		kk := rand.Intn(len(headingForPlaces))
+	return candidates, nil
+}
 
-		s := headingStart1[jj]
-		p := headingForPlaces[kk]
-		heading := strings.Join([]string{s, "in", city, p, poi}, " ")
-		headings = append(headings, heading)
+// generate executes the heading templates suiting the tone. It is only called while the generator is locked.
+func (hg *HeadingGenerator) generate(data HeadingData) (ArticleHeadings, error) {
+	headings := ArticleHeadings{}
+	for _, tmpl := range hg.suiting(data.Tone) {
+		b := &strings.Builder{}
+		if err := tmpl.Execute(b, data); err != nil {
+			return nil, errors.Wrapf(err, "failure to create heading from template %s", tmpl.Name())
+		}
+		headings = append(headings, hg.catalog.Polish(b.String()))
 	}
 
-	return headings
+	return headings, nil
 }
 
-// GetTopLocation ...
-func GetTopLocation(articleLocation []photo.LocationM) (string, string, error) {
-	if len(articleLocation) <= 0 {
-		return "", "", nil
+// suiting provides the templates suiting the tone, or all templates if none suits it.
+func (hg *HeadingGenerator) suiting(t tone.Tone) []*template.Template {
+	suiting := []*template.Template{}
+	for i, tmpl := range hg.templates {
+		if t.Suits(hg.sources[i]) {
+			suiting = append(suiting, tmpl)
+		}
+	}
+	if len(suiting) == 0 {
+		return hg.templates
 	}
+	return suiting
+}
 
-	// determine the number of occurrencies.
-	countryM := map[string]int{}
-	cityM := map[string]int{}
-	for _, l := range articleLocation {
-		countryM[l.Location.Country] = countryM[l.Location.Country] + 1
-		cityM[l.Location.City] = cityM[l.Location.City] + 1
+// Suggest selects the suggested headings from the oversampled candidates: the diverse best scored
+// headings, as many as there are templates suiting the tone (or top, if positive and fewer), scoring
+// at least minScore.
+// The phrases of the suggested headings are added to the phrase book.
+func (hg *HeadingGenerator) Suggest(data HeadingData, shares Shares, w ScoreWeights, d Diversity,
+	top int, minScore float64, pb *PhraseBook,
+) ([]ScoredHeading, error) {
+	if d == (Diversity{}) {
+		d = DefaultDiversity
 	}
 
-	// find the most frequent occurrence of a country.
-	topLocationL := SortPositionList{}
-	for k, v := range countryM {
-		topLocationL = append(topLocationL, SortPosition{
-			name:  k,
-			count: v,
-		})
+	candidates, err := hg.Candidates(data, d.Oversample)
+	if err != nil {
+		return nil, err
 	}
-	sort.Sort(sort.Reverse((topLocationL)))
-	topCountry := topLocationL[0].name
 
-	// find the most frequent occurrence of a city.
-	topLocationL = SortPositionList{}
-	for k, v := range cityM {
-		topLocationL = append(topLocationL, SortPosition{
-			name:  k,
-			count: v,
-		})
+	n := len(hg.suiting(data.Tone))
+	if top > 0 && top < n {
+		n = top
 	}
-	sort.Sort(sort.Reverse((topLocationL)))
-	topCity := topLocationL[0].name
+	scored := SelectHeadings(hg.Score(candidates, data, shares, w), 0, minScore)
+	diverse := SelectDiverse(scored, n, d.Lambda, pb)
+	pb.Add(HeadingsOf(diverse)...)
 
-	return topCountry, topCity, nil
+	return diverse, nil
 }
 
-// GetTopWeather ...
-func GetTopWeather(weatherData []photo.WeatherM) string {
-	// determine the number of occurrencies.
-	weather := map[string]int{}
-	for _, w := range weatherData {
-		weather[w.Weather] = weather[w.Weather] + 1
+// localize translates the heading data and replaces the vocabulary with that of the catalog.
+func (hg *HeadingGenerator) localize(data HeadingData) HeadingData {
+	c := hg.catalog
+	if len(c.Messages) == 0 {
+		return data
 	}
 
-	// find the most frequent occurrence of weather.
-	topWeatherL := SortPositionList{}
-	for k, v := range weather {
-		topWeatherL = append(topWeatherL, SortPosition{
-			name:  k,
-			count: v,
-		})
+	if w := strings.TrimPrefix(data.Weather, mostly); w != data.Weather {
+		data.Weather = fmt.Sprintf(c.T(mostly+"%s"), c.T(w))
+	} else {
+		data.Weather = c.T(data.Weather)
+	}
+	data.Weekday = c.T(data.Weekday)
+	data.Month = c.T(data.Month)
+	data.Season = c.T(data.Season)
+	data.TimeOfDay = c.T(data.TimeOfDay)
+	data.PlaceOfInterest = c.T(data.PlaceOfInterest)
+	places := make([]string, 0, len(data.PlacesOfInterest))
+	for _, p := range data.PlacesOfInterest {
+		places = append(places, c.T(p))
+	}
+	data.PlacesOfInterest = places
+	data.Holiday = c.T(data.Holiday)
+	data.Duration = c.T(data.Duration)
+
+	vocabulary := []struct {
+		phrases *[]string
+		l10n    []string
+	}{
+		{&data.Starts, c.Vocabulary.Starts},
+		{&data.HappyStarts, c.Vocabulary.HappyStarts},
+		{&data.Company, c.Vocabulary.Company},
+		{&data.ForPlaces, c.Vocabulary.ForPlaces},
+		{&data.Adjectives, c.Vocabulary.Adjectives},
+	}
+	for _, v := range vocabulary {
+		if len(v.l10n) > 0 {
+			*v.phrases = v.l10n
+		}
 	}
-	sort.Sort(sort.Reverse((topWeatherL)))
-	topWeather := topWeatherL[0].name
 
-	return topWeather
+	return data
 }
 
-// GetTopTimeInfo ...
-func GetTopTimeInfo(weatherData []photo.WeatherM) (string, string, string) {
-	// determine the number of occurrencies.
-	weekday := map[string]int{}
-	month := map[string]int{}
-	season := map[string]int{}
-	for _, w := range weatherData {
-		ti := w.TimeInfo
-		weekday[ti.Weekday] = weekday[ti.Weekday] + 1
-		month[ti.Month] = month[ti.Month] + 1
-		season[ti.Season] = season[ti.Season] + 1
-	}
-
-	// find the most frequent occurrence of weekday.
-	topWeekdayL := SortPositionList{}
-	for k, v := range weekday {
-		topWeekdayL = append(topWeekdayL, SortPosition{
-			name:  k,
-			count: v,
-		})
-	}
-	sort.Sort(sort.Reverse((topWeekdayL)))
-	topWeekday := topWeekdayL[0].name
-
-	// find the most frequent occurrence of month.
-	topMonthL := SortPositionList{}
-	for k, v := range month {
-		topMonthL = append(topMonthL, SortPosition{
-			name:  k,
-			count: v,
-		})
-	}
-	sort.Sort(sort.Reverse((topMonthL)))
-	topMonth := topMonthL[0].name
-
-	// find the most frequent occurrence of season.
-	topSeasonL := SortPositionList{}
-	for k, v := range season {
-		topSeasonL = append(topSeasonL, SortPosition{
-			name:  k,
-			count: v,
-		})
-	}
-	sort.Sort(sort.Reverse((topSeasonL)))
-	topSeason := topSeasonL[0].name
-
-	return topWeekday, topMonth, topSeason
-}
+// Profile fits the headings to each of the profiles. The profile casing is only applied to English
+// headings, the templates of the other locales follow the casing rules of their language.
+func (hg *HeadingGenerator) Profile(headings ArticleHeadings, data HeadingData,
+	profiles []profile.Profile,
+) []profile.Heading {
+	if len(profiles) == 0 {
+		return nil
+	}
+	data = hg.localize(data)
 
-func GetTopPlaceOfInterest(poiData []photo.PoiM) string {
-	// determine the number of occurrencies.
-	poi := map[string]int{}
-	for _, p := range poiData {
-		pp := p.POI
-		for k, v := range pp {
-			poi[k] = poi[k] + v
+	proper := append([]string{data.Country, data.City, data.Region, data.Holiday, data.Month}, data.Itinerary...)
+	if data.Weekday != "Weekend" {
+		proper = append(proper, data.Weekday)
+	}
+
+	profiled := []profile.Heading{}
+	for _, p := range profiles {
+		if hg.catalog.Locale != locale.English {
+			p.Casing = profile.AsIs
+		}
+		for _, h := range headings {
+			profiled = append(profiled, p.Apply(h, proper...))
 		}
 	}
+	return profiled
+}
 
-	// find the most frequent occurrence of poi.
-	topPOIL := SortPositionList{}
-	for k, v := range poi {
-		topPOIL = append(topPOIL, SortPosition{
-			name:  k,
-			count: v,
-		})
+// pick chooses a random phrase. It is only called while the generator is locked.
+func (hg *HeadingGenerator) pick(phrases []string) string {
+	if len(phrases) == 0 {
+		return ""
 	}
-	sort.Sort(sort.Reverse((topPOIL)))
-	topPOI := topPOIL[0].name
+	return phrases[hg.rnd.Intn(len(phrases))]
+}
 
-	return topPOI
+// NewHeadingData provides the most frequent article information together with
+// the default vocabulary.
+func NewHeadingData(articleLocationData []photo.LocationM, articleWeatherData []photo.WeatherM,
+	articlePOIData []photo.PoiM,
+) HeadingData {
+	return Weights(nil).HeadingData(articleLocationData, articleWeatherData, articlePOIData)
 }
 
-// PresentSuggestedHeadings ...
-func PresentSuggestedHeadings(alb string, headings ArticleHeadings) {
-	fmt.Printf("---------------------------------------\n")
-	log.Printf("%s\n\n", alb)
-	for _, t := range headings {
-		fmt.Printf("\t%s\n", t)
+// HeadingData is NewHeadingData with weighted photos.
+func (w Weights) HeadingData(articleLocationData []photo.LocationM, articleWeatherData []photo.WeatherM,
+	articlePOIData []photo.PoiM,
+) HeadingData {
+	country, city, errLoc := w.TopLocation(articleLocationData)
+	if errLoc != nil {
+		city = unknownCity
+		if country == "" {
+			country = unknownCountry
+		}
+	}
+	// the weather is only stated flatly if it is dominant, eg "mostly sunny" otherwise.
+	weathers := w.WeatherRanking(articleWeatherData)
+	weather := weathers.First().Name
+	if weather != "" && !weathers.Dominant() {
+		weather = mostly + weather
+	}
+	weekday, month, season := w.TopTimeInfo(articleWeatherData)
+	if weekday == "Saturday" || weekday == "Sunday" {
+		weekday = "Weekend"
+	}
+
+	poi := w.TopPlaceOfInterest(articlePOIData)
+
+	return HeadingData{
+		Country:         country,
+		City:            city,
+		Weather:         weather,
+		Weekday:         weekday,
+		Month:           month,
+		Season:          season,
+		TimeOfDay:       w.TimeOfDayRanking(articleWeatherData).First().Name,
+		PlaceOfInterest: poi,
+		Holiday:         w.HolidayRanking(articleWeatherData).First().Name,
+
+		Starts: []string{"Enjoy your break", "Having a great time",
+			"Experience of a lifetime", "Have a holiday of a lifetime", "Wonderful break"},
+		HappyStarts: []string{"Enjoy happy days", "Have a hilarious time"},
+		Company:     []string{"with friends", "with family", "on your own"},
+		ForPlaces:   []string{"full of", "bursting with", "brimming with", "packed with"},
+		Adjectives:  []string{"Hilarious", "Beautiful", "Brilliant", "Family fun", "Glorious"},
 	}
-	fmt.Printf("---------------------------------------\n")
 }
 
-// Custom sorting to determine the highest ranking of Photo attributes.
-func (spl SortPositionList) Len() int           { return len(spl) }
-func (spl SortPositionList) Less(i, j int) bool { return spl[i].count < spl[j].count }
-func (spl SortPositionList) Swap(i, j int)      { spl[i], spl[j] = spl[j], spl[i] }
+// GetTopLocation ...
+func GetTopLocation(articleLocation []photo.LocationM) (string, string, error) {
+	return Weights(nil).TopLocation(articleLocation)
+}
+
+// GetTopWeather ...
+func GetTopWeather(weatherData []photo.WeatherM) string {
+	return Weights(nil).TopWeather(weatherData)
+}
+
+// GetTopTimeInfo ...
+func GetTopTimeInfo(weatherData []photo.WeatherM) (string, string, string) {
+	return Weights(nil).TopTimeInfo(weatherData)
+}
+
+func GetTopPlaceOfInterest(poiData []photo.PoiM) string {
+	return Weights(nil).TopPlaceOfInterest(poiData)
+}
@@ -3,12 +3,14 @@
 package service_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tamarakaufler/travel-article-headings/internal/service"
 
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
 )
 
 func TestGetTopLocation(t *testing.T) {
@@ -16,11 +18,27 @@ func TestGetTopLocation(t *testing.T) {
 		articleLocation []photo.LocationM
 	}
 	tests := []struct {
-		name  string
-		args  args
-		want  string
-		want1 string
+		name    string
+		args    args
+		want    string
+		want1   string
+		wantErr bool
 	}{
+		{
+			name:    "no location data",
+			args:    args{},
+			wantErr: true,
+		},
+		{
+			name: "country without a city",
+			args: args{
+				articleLocation: []photo.LocationM{
+					{ArticleID: "AAA", PhotoID: 1, Location: photo.Location{Country: "Italy"}},
+				},
+			},
+			want:    "Italy",
+			wantErr: true,
+		},
 		{
 			name: "location, weather and poi data fully provided",
 			args: args{
@@ -106,7 +124,11 @@ func TestGetTopLocation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, got1, err := service.GetTopLocation(tt.args.articleLocation)
-			require.NoError(t, err)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
 
 			if got != tt.want {
 				t.Errorf("GetTopLocation() got = %v, want %v", got, tt.want)
@@ -117,3 +139,192 @@ func TestGetTopLocation(t *testing.T) {
 		})
 	}
 }
+
+func TestNewHeadingData_UnknownLocation(t *testing.T) {
+	tests := []struct {
+		name        string
+		locations   []photo.LocationM
+		wantCountry string
+		wantCity    string
+	}{
+		{name: "no location", wantCountry: "country", wantCity: "city"},
+		{
+			name:        "country without a city",
+			locations:   []photo.LocationM{{PhotoID: 1, Location: photo.Location{Country: "Italy"}}},
+			wantCountry: "Italy",
+			wantCity:    "city",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := service.NewHeadingData(tt.locations, nil, nil)
+			require.Equal(t, tt.wantCountry, data.Country)
+			require.Equal(t, tt.wantCity, data.City)
+		})
+	}
+}
+
+func TestGenerate_Route(t *testing.T) {
+	tests := []struct {
+		name      string
+		itinerary []string
+		region    string
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "road trip",
+			itinerary: []string{"Sorrento", "Amalfi", "Naples"},
+			region:    "Campania",
+			want:      []string{"From Sorrento to Naples", "Three cities of Campania"},
+		},
+		{
+			name:      "international trip",
+			itinerary: []string{"Vienna", "Prague"},
+			want:      []string{"From Vienna to Prague", "Two cities packed with"},
+		},
+		{
+			name:      "one stop",
+			itinerary: []string{"Sorrento"},
+			region:    "Campania",
+			want:      []string{"stay in Sorrento", "in Sorrento packed with"},
+			notWant:   []string{"From ", "cities"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hg, err := service.NewHeadingGenerator(service.DefaultTemplates, 1)
+			require.NoError(t, err)
+
+			data := service.HeadingData{
+				Country:         "Italy",
+				City:            "Sorrento",
+				PlaceOfInterest: "Cafes",
+				Itinerary:       tt.itinerary,
+				Region:          tt.region,
+				ForPlaces:       []string{"packed with"},
+			}
+			headings, err := hg.Generate(data)
+			require.NoError(t, err)
+			require.Len(t, headings, len(service.DefaultTemplates))
+
+			all := strings.Join(headings, "\n")
+			for _, w := range tt.want {
+				require.Contains(t, all, w)
+			}
+			for _, w := range tt.notWant {
+				require.NotContains(t, all, w)
+			}
+		})
+	}
+}
+
+func TestGenerate_Holiday(t *testing.T) {
+	tests := []struct {
+		name    string
+		holiday string
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "Christmas markets",
+			holiday: "Christmas markets",
+			want:    []string{"Christmas markets in Vienna packed with", "Christmas markets break in Austria"},
+			notWant: []string{"winter break"},
+		},
+		{
+			name:    "no holiday",
+			want:    []string{"winter break in Austria"},
+			notWant: []string{"Christmas"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hg, err := service.NewHeadingGenerator(service.DefaultTemplates, 1)
+			require.NoError(t, err)
+
+			data := service.HeadingData{
+				Country:         "Austria",
+				City:            "Vienna",
+				Season:          "Winter",
+				PlaceOfInterest: "Cafes",
+				Holiday:         tt.holiday,
+				ForPlaces:       []string{"packed with"},
+			}
+			headings, err := hg.Generate(data)
+			require.NoError(t, err)
+
+			all := strings.Join(headings, "\n")
+			for _, w := range tt.want {
+				require.Contains(t, all, w)
+			}
+			for _, w := range tt.notWant {
+				require.NotContains(t, all, w)
+			}
+		})
+	}
+}
+
+func TestGenerate_Grammar(t *testing.T) {
+	hg, err := service.NewHeadingGenerator(service.DefaultTemplates, 1)
+	require.NoError(t, err)
+
+	data := service.HeadingData{
+		Country:         "USA",
+		City:            "New York",
+		Weather:         "boiling hot",
+		Weekday:         "Weekend",
+		Season:          "Summer",
+		PlaceOfInterest: "Museum",
+		Starts:          []string{"Enjoy your break"},
+		Company:         []string{"with family"},
+		ForPlaces:       []string{"packed with"},
+		Adjectives:      []string{"Glorious"},
+	}
+	headings, err := hg.Generate(data)
+	require.NoError(t, err)
+
+	all := strings.Join(headings, "\n")
+	for _, want := range []string{
+		"Enjoy your break with family in the boiling hot USA",
+		"Glorious weekend enjoying the museums of New York",
+		"Glorious summer break in the USA packed with museums",
+	} {
+		require.Contains(t, all, want)
+	}
+	for _, notWant := range []string{"of packed", "in boiling hot USA", "Museum", "Weekend", "Summer"} {
+		require.NotContains(t, all, notWant)
+	}
+}
+
+func TestGenerate_Tone(t *testing.T) {
+	hg, err := service.NewHeadingGenerator(service.DefaultTemplates, 1)
+	require.NoError(t, err)
+
+	data := service.NewHeadingData(
+		[]photo.LocationM{{PhotoID: 1, Location: photo.Location{Country: "Italy", City: "Sorrento"}}},
+		[]photo.WeatherM{{PhotoID: 1, Weather: "sunny"}},
+		[]photo.PoiM{{PhotoID: 1, POI: map[string]int{"Restaurants": 3}}},
+	).WithTone(tone.Romantic)
+
+	require.Equal(t, "romantic", data.Tone.Name)
+	require.Equal(t, tone.Romantic.Adjectives, data.Adjectives)
+	// the tone without happy starts keeps the default ones.
+	require.NotEmpty(t, data.HappyStarts)
+
+	headings, err := hg.Generate(data)
+	require.NoError(t, err)
+
+	// the happy starts template does not suit the romantic tone.
+	require.Len(t, headings, len(service.DefaultTemplates)-1)
+	for _, h := range headings {
+		require.NotContains(t, h, "Hilarious")
+		require.NotContains(t, h, "with friends")
+	}
+
+	// no template suits the tone avoiding all of them.
+	data.Tone = tone.Tone{Avoids: []string{"City", "Country"}}
+	headings, err = hg.Generate(data)
+	require.NoError(t, err)
+	require.Len(t, headings, len(service.DefaultTemplates))
+}
@@ -0,0 +1,18 @@
+package service
+
+// MinLandmarkShare is the least share of the photos (by weight) taken near a landmark for the headings
+// to name it, so that a landmark passed by once does not name a whole trip.
+const MinLandmarkShare = 0.3
+
+// landmarkDistance is the distance from the photo (in meters) halving the count of a landmark.
+const landmarkDistance = 100.0
+
+// landmark is the top ranked landmark recurring near at least MinLandmarkShare of the photos, if any.
+func landmark(landmarks Ranking) string {
+	for _, l := range landmarks {
+		if l.Share >= MinLandmarkShare {
+			return l.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+// +build service_tests
+
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+// landmarkClientM names the landmarks near the photos by photo ID.
+type landmarkClientM struct {
+	poiClientM
+	landmarks map[int][]photo.Landmark
+}
+
+func (lc landmarkClientM) PlacesAndLandmarks(ctx context.Context, pd photo.Data,
+) (map[string]int, []photo.Landmark, error) {
+	pois, err := lc.PlacesOfInterest(ctx, pd)
+	return pois, lc.landmarks[pd.ID], err
+}
+
+func TestProcessArticle_Landmark(t *testing.T) {
+	photoL := []photo.Data{
+		{ArticleID: "article1", ID: 1, Date: "2019-10-27T13:27:58Z",
+			LatLon: photo.LatLon{Latitude: "41.890", Longitude: "12.492"}},
+		{ArticleID: "article1", ID: 2, Date: "2019-10-27T14:27:58Z",
+			LatLon: photo.LatLon{Latitude: "41.891", Longitude: "12.491"}},
+		{ArticleID: "article1", ID: 3, Date: "2019-10-27T15:27:58Z",
+			LatLon: photo.LatLon{Latitude: "41.901", Longitude: "12.483"}},
+		{ArticleID: "article1", ID: 4, Date: "2019-10-27T16:27:58Z",
+			LatLon: photo.LatLon{Latitude: "41.902", Longitude: "12.476"}},
+	}
+
+	colosseum := photo.Landmark{Name: "Colosseum", Distance: 120, Popularity: 300000}
+	tests := []struct {
+		name      string
+		landmarks map[int][]photo.Landmark
+		want      string
+		wantShare float64
+	}{
+		{
+			name: "recurring",
+			landmarks: map[int][]photo.Landmark{
+				1: {colosseum},
+				2: {colosseum, {Name: "Arch of Constantine", Distance: 40, Popularity: 20000}},
+				3: {{Name: "Trevi Fountain", Distance: 30, Popularity: 400000}},
+			},
+			want:      "Colosseum",
+			wantShare: 0.5,
+		},
+		{
+			name: "passed by once",
+			landmarks: map[int][]photo.Landmark{
+				1: {{Name: "Arch of Constantine", Distance: 40, Popularity: 20000}},
+				2: {{Name: "Ludus Magnus", Distance: 20, Popularity: 900}},
+				3: {{Name: "Trevi Fountain", Distance: 30, Popularity: 400000}},
+			},
+			// every landmark is near a quarter of the photos.
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			as, _, _ := setup("article1")
+			as.Clients.POI = landmarkClientM{landmarks: tt.landmarks}
+
+			a, err := as.ProcessArticle(context.Background(), "article1", photoL)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, a.Summary.Landmark)
+			require.InDelta(t, tt.wantShare, a.Summary.Shares.Landmark, 0.0001)
+
+			named := false
+			for _, h := range a.Headings {
+				named = named || (tt.want != "" && strings.Contains(h, tt.want))
+			}
+			require.Equal(t, tt.want != "", named, a.Headings)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
+)
+
+// Places determines the selection of the most distinctive places of interest mentioned by the headings.
+type Places struct {
+	// Baseline holds the usual shares of the places of interest, the distinctiveness of the places
+	// near the photos is measured against.
+	Baseline *poi.Baseline
+	// Distinctive is the most places of interest mentioned by the headings.
+	Distinctive int
+}
+
+// DefaultDistinctivePlaces is the number of the most distinctive places of interest mentioned by the headings
+// with the pkg/headings library and the command line.
+const DefaultDistinctivePlaces = 2
+
+// distinctive selects up to Distinctive (at least one) places of interest from the distinctiveness ranking:
+// the top place preferred by the tone, or the most distinctive one, followed by the other places more common
+// than usual.
+func (p Places) distinctive(pois Ranking, t tone.Tone) []string {
+	if len(pois) == 0 {
+		return nil
+	}
+
+	top := pois.First().Name
+	for _, r := range pois {
+		if t.Prefers(r.Name) {
+			top = r.Name
+			break
+		}
+	}
+
+	places := []string{top}
+	for _, r := range pois {
+		if len(places) >= p.Distinctive {
+			break
+		}
+		if r.Name != top && r.Weight > 0 {
+			places = append(places, r.Name)
+		}
+	}
+	return places
+}
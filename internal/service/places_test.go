@@ -0,0 +1,69 @@
+// +build service_tests
+
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+func TestProcessArticle_Places(t *testing.T) {
+	photoL := []photo.Data{
+		{ArticleID: "article1", ID: 1, Date: "2019-10-27T13:27:58Z",
+			LatLon: photo.LatLon{Latitude: "50.087", Longitude: "14.421"}},
+		{ArticleID: "article1", ID: 2, Date: "2019-10-27T14:27:58Z",
+			LatLon: photo.LatLon{Latitude: "50.088", Longitude: "14.422"}},
+	}
+
+	cafes, err := poi.New(strings.NewReader(`{"global": {"Cafes": 0.6}}`))
+	require.NoError(t, err)
+
+	// the places of interest are Cafes (1/2), Restaurants (1/3) and Cinemas (1/6).
+	tests := []struct {
+		name   string
+		places service.Places
+		tone   string
+		want   []string
+	}{
+		{
+			name: "by share",
+			want: []string{"Cafes"},
+		},
+		{
+			name:   "distinctive",
+			places: service.Places{Baseline: poi.Default(), Distinctive: 2},
+			want:   []string{"Cafes", "Cinemas"},
+		},
+		{
+			name:   "more common than usual",
+			places: service.Places{Baseline: poi.Default().Extend(cafes), Distinctive: 3},
+			want:   []string{"Cinemas", "Restaurants"},
+		},
+		{
+			name:   "preferred by the tone",
+			places: service.Places{Baseline: poi.Default(), Distinctive: 3},
+			tone:   "nightlife",
+			want:   []string{"Cinemas", "Cafes", "Restaurants"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			as, _, _ := setup("article1")
+			as.Places = tt.places
+			as.Tone = tt.tone
+
+			a, err := as.ProcessArticle(context.Background(), "article1", photoL)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, a.Summary.PlacesOfInterest)
+			require.Equal(t, tt.want[0], a.Summary.PlaceOfInterest)
+		})
+	}
+}
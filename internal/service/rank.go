@@ -0,0 +1,87 @@
+package service
+
+import "sort"
+
+// DominantShare is the least share of the top item to be stated flatly in the headings,
+// eg "sunny" rather than "mostly sunny".
+const DominantShare = 0.6
+
+// Ranked is an item with its weight and its share (0-1) of the total weight.
+type Ranked struct {
+	Name   string
+	Weight float64
+	Share  float64
+}
+
+// Ranking holds the items ordered by weight.
+type Ranking []Ranked
+
+// Rank orders the items by weight, the heaviest first. Ties are broken by the item name
+// in alphabetical order, with an empty name (unknown information) last, so the ranking
+// does not depend on the map iteration order.
+func Rank(weights map[string]float64) Ranking {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	r := Ranking{}
+	for name, w := range weights {
+		ranked := Ranked{Name: name, Weight: w}
+		if total > 0 {
+			ranked.Share = w / total
+		}
+		r = append(r, ranked)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Weight != r[j].Weight {
+			return r[i].Weight > r[j].Weight
+		}
+		if r[i].Name == "" || r[j].Name == "" {
+			return r[j].Name == ""
+		}
+		return r[i].Name < r[j].Name
+	})
+
+	return r
+}
+
+// Top provides the k heaviest items.
+func (r Ranking) Top(k int) Ranking {
+	if k < len(r) {
+		return r[:k]
+	}
+	return r
+}
+
+// First is the heaviest item. It is empty for an empty ranking.
+func (r Ranking) First() Ranked {
+	if len(r) == 0 {
+		return Ranked{}
+	}
+	return r[0]
+}
+
+// Dominant is true if the heaviest item has at least the DominantShare of the total weight.
+func (r Ranking) Dominant() bool {
+	return r.First().Share >= DominantShare
+}
+
+// ShareOf provides the share of the named item.
+func (r Ranking) ShareOf(name string) float64 {
+	for _, ranked := range r {
+		if ranked.Name == name {
+			return ranked.Share
+		}
+	}
+	return 0
+}
+
+// Names lists the item names.
+func (r Ranking) Names() []string {
+	names := []string{}
+	for _, ranked := range r {
+		names = append(names, ranked.Name)
+	}
+	return names
+}
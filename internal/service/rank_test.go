@@ -0,0 +1,148 @@
+// +build unit_tests
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name         string
+		weights      map[string]float64
+		k            int
+		want         []string
+		wantShare    float64
+		wantDominant bool
+	}{
+		{
+			name:         "by weight",
+			weights:      map[string]float64{"rainy": 1, "sunny": 6, "hazy": 3},
+			k:            3,
+			want:         []string{"sunny", "hazy", "rainy"},
+			wantShare:    0.6,
+			wantDominant: true,
+		},
+		{
+			name:      "ties broken alphabetically",
+			weights:   map[string]float64{"sunny": 2, "rainy": 2, "hazy": 2, "wet": 2, "stormy": 1},
+			k:         4,
+			want:      []string{"hazy", "rainy", "sunny", "wet"},
+			wantShare: 2.0 / 9,
+		},
+		{
+			name:      "unknown last",
+			weights:   map[string]float64{"": 2, "Sorrento": 2, "Amalfi": 1},
+			k:         3,
+			want:      []string{"Sorrento", "", "Amalfi"},
+			wantShare: 0.4,
+		},
+		{
+			name:    "top k",
+			weights: map[string]float64{"Cafes": 3, "Restaurants": 2, "Cinemas": 1},
+			k:       2,
+			want:    []string{"Cafes", "Restaurants"},
+			// 3 of 6
+			wantShare: 0.5,
+		},
+		{
+			name:    "empty",
+			weights: map[string]float64{},
+			k:       2,
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the ranking does not depend on the map iteration order.
+			for i := 0; i < 20; i++ {
+				r := service.Rank(tt.weights).Top(tt.k)
+				require.Equal(t, tt.want, r.Names())
+				require.InDelta(t, tt.wantShare, r.First().Share, 0.0001)
+				require.Equal(t, tt.wantDominant, r.Dominant())
+			}
+		})
+	}
+}
+
+func TestHeadingData_Weather(t *testing.T) {
+	weathers := func(ws ...string) []photo.WeatherM {
+		wms := []photo.WeatherM{}
+		for i, w := range ws {
+			wms = append(wms, photo.WeatherM{PhotoID: i + 1, Weather: w})
+		}
+		return wms
+	}
+
+	tests := []struct {
+		name     string
+		weathers []photo.WeatherM
+		want     string
+	}{
+		{
+			name:     "dominant",
+			weathers: weathers("sunny", "sunny", "sunny", "rainy"),
+			want:     "sunny",
+		},
+		{
+			name:     "not dominant",
+			weathers: weathers("sunny", "sunny", "rainy", "hazy", "wet"),
+			want:     "mostly sunny",
+		},
+		{
+			name:     "tie",
+			weathers: weathers("sunny", "rainy"),
+			want:     "mostly rainy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := service.NewHeadingData(nil, tt.weathers, nil)
+			require.Equal(t, tt.want, data.Weather)
+		})
+	}
+}
+
+func TestHeadingData_Holiday(t *testing.T) {
+	weathers := func(hs ...[]string) []photo.WeatherM {
+		wms := []photo.WeatherM{}
+		for i, h := range hs {
+			wms = append(wms, photo.WeatherM{PhotoID: i + 1, Weather: "sunny", TimeInfo: photo.TimeInfo{Holidays: h}})
+		}
+		return wms
+	}
+	markets := []string{"Christmas markets"}
+	eve := []string{"Christmas markets", "Christmas"}
+
+	tests := []struct {
+		name     string
+		weathers []photo.WeatherM
+		want     string
+	}{
+		{
+			name:     "most photos on a holiday",
+			weathers: weathers(markets, eve, eve, nil),
+			want:     "Christmas markets",
+		},
+		{
+			name:     "most photos on no holiday",
+			weathers: weathers(markets, nil, nil),
+			want:     "",
+		},
+		{
+			name:     "no holidays",
+			weathers: weathers(nil, nil),
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := service.NewHeadingData(nil, tt.weathers, nil)
+			require.Equal(t, tt.want, data.Holiday)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScoredHeading is a heading candidate with its score (0-1) and the score components (0-1).
+type ScoredHeading struct {
+	Heading string
+	Score   float64
+
+	// Specificity of the information mentioned in the heading, eg the city rather than the country.
+	Specificity float64
+	// Confidence is the mean share of the photos with the information mentioned in the heading.
+	Confidence float64
+	// Length is 1 for headings of IdealMinLength to IdealMaxLength characters.
+	Length float64
+	// Readability penalizes long words and wordy headings.
+	Readability float64
+	// Redundancy is the repetition of words within the heading or of a better-ranked heading.
+	Redundancy float64
+}
+
+// ScoreWeights determine how much each score component counts. The score is the weighted
+// mean of the components, the Redundancy counting as its complement.
+type ScoreWeights struct {
+	Specificity float64
+	Confidence  float64
+	Length      float64
+	Readability float64
+	Redundancy  float64
+}
+
+// DefaultScoreWeights are used if no score weights are provided.
+var DefaultScoreWeights = ScoreWeights{
+	Specificity: 0.3,
+	Confidence:  0.25,
+	Length:      0.15,
+	Readability: 0.15,
+	Redundancy:  0.15,
+}
+
+// Ideal heading length (characters).
+const (
+	IdealMinLength = 30
+	IdealMaxLength = 70
+)
+
+// readability limits.
+const (
+	maxWords      = 12
+	maxWordLength = 7
+)
+
+// fact is a piece of heading information, how specific it is and the share of the photos with it.
+// Names (of places) are matched case sensitively, so that eg Nice is not the nice weather.
+type fact struct {
+	value       string
+	specificity float64
+	share       float64
+	name        bool
+}
+
+// Score scores and sorts the headings created from the data, best first. The shares
+// are the shares of the photos with the data information. Headings with the same
+// score keep their order.
+func (hg *HeadingGenerator) Score(headings ArticleHeadings, data HeadingData, shares Shares,
+	w ScoreWeights,
+) []ScoredHeading {
+	if w == (ScoreWeights{}) {
+		w = DefaultScoreWeights
+	}
+	known := data.City != unknownCity
+	data = hg.localize(data)
+
+	facts := []fact{
+		{data.Weather, 0.1, shares.Weather, false},
+		{data.Weekday, 0.1, shares.Weekday, false},
+		{data.Month, 0.1, shares.Month, false},
+		{data.Season, 0.1, shares.Season, false},
+		{data.TimeOfDay, 0.1, shares.TimeOfDay, false},
+		{data.PlaceOfInterest, 0.2, shares.PlaceOfInterest, false},
+		{data.Holiday, 0.2, shares.Holiday, false},
+		{data.Duration, 0.1, 1, false},
+	}
+	if known {
+		facts = append(facts, fact{data.City, 0.4, shares.City, true}, fact{data.Country, 0.1, shares.Country, true})
+		if data.Route() {
+			facts = append(facts,
+				fact{data.Region, 0.2, 1, true}, fact{data.From(), 0.4, 1, true}, fact{data.To(), 0.4, 1, true})
+		}
+	}
+
+	scored := make([]ScoredHeading, 0, len(headings))
+	for _, h := range headings {
+		sh := ScoredHeading{
+			Heading:     h,
+			Length:      lengthScore(h),
+			Readability: readability(h),
+		}
+		sh.Specificity, sh.Confidence = mentions(h, facts)
+		// headings mentioning the fallback location are not specific at all.
+		if !known && mentionsWord(h, unknownCity, unknownCountry) {
+			sh.Specificity = 0
+		}
+		scored = append(scored, sh)
+	}
+
+	// the headings are ranked without the redundancy first, so that the redundancy
+	// of a heading is that of the better-ranked ones.
+	for i := range scored {
+		scored[i].Redundancy = repetition(scored[i].Heading)
+		scored[i].Score = w.score(scored[i])
+	}
+	sortScored(scored)
+
+	for i := range scored {
+		sh := &scored[i]
+		for _, better := range scored[:i] {
+			sh.Redundancy = math.Max(sh.Redundancy, similarity(sh.Heading, better.Heading))
+		}
+		sh.Score = w.score(*sh)
+	}
+	sortScored(scored)
+
+	return scored
+}
+
+// score is the weighted mean of the score components.
+func (w ScoreWeights) score(sh ScoredHeading) float64 {
+	total := w.Specificity + w.Confidence + w.Length + w.Readability + w.Redundancy
+	if total <= 0 {
+		return 0
+	}
+	return (w.Specificity*sh.Specificity + w.Confidence*sh.Confidence + w.Length*sh.Length +
+		w.Readability*sh.Readability + w.Redundancy*(1-sh.Redundancy)) / total
+}
+
+func sortScored(scored []ScoredHeading) {
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+}
+
+// SelectHeadings provides the top scored headings (all if top is not positive) scoring at least minScore.
+func SelectHeadings(scored []ScoredHeading, top int, minScore float64) []ScoredHeading {
+	selected := []ScoredHeading{}
+	for _, sh := range scored {
+		if top > 0 && len(selected) == top {
+			break
+		}
+		if sh.Score >= minScore {
+			selected = append(selected, sh)
+		}
+	}
+	return selected
+}
+
+// HeadingsOf provides the scored headings.
+func HeadingsOf(scored []ScoredHeading) ArticleHeadings {
+	headings := ArticleHeadings{}
+	for _, sh := range scored {
+		headings = append(headings, sh.Heading)
+	}
+	return headings
+}
+
+// mentions provides the specificity and the confidence of the facts mentioned in the heading.
+func mentions(heading string, facts []fact) (float64, float64) {
+	lower := strings.ToLower(heading)
+
+	specificity, shares, n := 0.0, 0.0, 0
+	seen := map[string]bool{}
+	for _, f := range facts {
+		v := strings.ToLower(f.value)
+		if v == "" || seen[v] {
+			continue
+		}
+		found := containsWord(lower, v)
+		if f.name {
+			found = containsWord(heading, f.value)
+		}
+		if !found {
+			continue
+		}
+		seen[v] = true
+		specificity += f.specificity
+		shares += f.share
+		n++
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return math.Min(specificity, 1), shares / float64(n)
+}
+
+// containsWord is true if the phrase is in the text as whole words, eg Rome is not in Romeo.
+func containsWord(text, phrase string) bool {
+	for i := strings.Index(text, phrase); i >= 0; {
+		end := i + len(phrase)
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+
+		next := strings.Index(text[i+1:], phrase)
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func mentionsWord(heading string, words ...string) bool {
+	for _, w := range contentWords(heading) {
+		for _, word := range words {
+			if w == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lengthScore(heading string) float64 {
+	n := float64(utf8.RuneCountInString(heading))
+	switch {
+	case n < IdealMinLength:
+		return n / IdealMinLength
+	case n > IdealMaxLength:
+		return math.Max(0, 1-(n-IdealMaxLength)/IdealMaxLength)
+	default:
+		return 1
+	}
+}
+
+func readability(heading string) float64 {
+	words := strings.Fields(heading)
+	if len(words) == 0 {
+		return 0
+	}
+	letters := 0
+	for _, word := range words {
+		letters += utf8.RuneCountInString(word)
+	}
+	mean := float64(letters) / float64(len(words))
+
+	wordy := math.Max(0, 1-math.Max(0, float64(len(words)-maxWords))/maxWords)
+	long := math.Max(0, 1-math.Max(0, mean-maxWordLength)/maxWordLength)
+	return wordy * long
+}
+
+// repetition is the share of the repeated content words (longer than 3 letters) of the heading.
+func repetition(heading string) float64 {
+	words := contentWords(heading)
+	if len(words) == 0 {
+		return 0
+	}
+	unique := map[string]bool{}
+	for _, w := range words {
+		unique[w] = true
+	}
+	return float64(len(words)-len(unique)) / float64(len(words))
+}
+
+// similarity is the Jaccard similarity of the heading words.
+func similarity(a, b string) float64 {
+	wa, wb := map[string]bool{}, map[string]bool{}
+	for _, w := range strings.Fields(strings.ToLower(a)) {
+		wa[w] = true
+	}
+	for _, w := range strings.Fields(strings.ToLower(b)) {
+		wb[w] = true
+	}
+
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	all := len(wa) + len(wb) - common
+	if all == 0 {
+		return 0
+	}
+	return float64(common) / float64(all)
+}
+
+func contentWords(heading string) []string {
+	words := []string{}
+	for _, w := range strings.Fields(strings.ToLower(heading)) {
+		w = strings.Trim(w, ".,:;!?'\"")
+		if utf8.RuneCountInString(w) > 3 {
+			words = append(words, w)
+		}
+	}
+	return words
+}
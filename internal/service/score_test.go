@@ -0,0 +1,232 @@
+// +build unit_tests
+
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
+	"github.com/tamarakaufler/travel-article-headings/internal/profile"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+func TestScore(t *testing.T) {
+	data := service.HeadingData{
+		Country:         "Italy",
+		City:            "Sorrento",
+		Weather:         "sunny",
+		Month:           "October",
+		PlaceOfInterest: "Cafes",
+	}
+	shares := service.Shares{Country: 1, City: 0.9, Weather: 0.8, Month: 1, PlaceOfInterest: 0.5}
+
+	hg, err := service.NewHeadingGenerator(nil, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     service.HeadingData
+		headings service.ArticleHeadings
+		want     service.ArticleHeadings
+	}{
+		{
+			name: "city is more specific than country",
+			data: data,
+			headings: service.ArticleHeadings{
+				"Wonderful October break in sunny Italy",
+				"Wonderful October break in sunny Sorrento",
+			},
+			want: service.ArticleHeadings{
+				"Wonderful October break in sunny Sorrento",
+				"Wonderful October break in sunny Italy",
+			},
+		},
+		{
+			name: "unknown location is not specific",
+			data: service.HeadingData{City: "city", Country: "country", Weather: "sunny", Month: "October"},
+			headings: service.ArticleHeadings{
+				"Wonderful October break in sunny city",
+				"Wonderful October break in sunny weather",
+			},
+			want: service.ArticleHeadings{
+				"Wonderful October break in sunny weather",
+				"Wonderful October break in sunny city",
+			},
+		},
+		{
+			name: "repeated heading is redundant",
+			data: data,
+			headings: service.ArticleHeadings{
+				"Sunny Sorrento full of Cafes in October",
+				"Sunny Sorrento full of Cafes in October",
+				"Enjoy October in Sorrento packed with Cafes",
+			},
+			want: service.ArticleHeadings{
+				"Sunny Sorrento full of Cafes in October",
+				"Enjoy October in Sorrento packed with Cafes",
+				"Sunny Sorrento full of Cafes in October",
+			},
+		},
+		{
+			name: "too long and too short headings",
+			data: data,
+			headings: service.ArticleHeadings{
+				"Sorrento",
+				"Sorrento, a charming town of Italy, full of Cafes and of sunny days of October and of sunny evenings",
+				"Sunny October in Sorrento full of Cafes",
+			},
+			want: service.ArticleHeadings{
+				"Sunny October in Sorrento full of Cafes",
+				"Sorrento, a charming town of Italy, full of Cafes and of sunny days of October and of sunny evenings",
+				"Sorrento",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scored := hg.Score(tt.headings, tt.data, shares, service.ScoreWeights{})
+			require.Equal(t, tt.want, service.HeadingsOf(scored))
+			for _, sh := range scored {
+				require.True(t, sh.Score >= 0 && sh.Score <= 1, sh.Score)
+			}
+		})
+	}
+}
+
+func TestScore_Mentions(t *testing.T) {
+	shares := service.Shares{Country: 1, City: 1, Weather: 1}
+
+	hg, err := service.NewHeadingGenerator(nil, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		data            service.HeadingData
+		heading         string
+		wantSpecificity float64
+	}{
+		{
+			name:            "city",
+			data:            service.HeadingData{Country: "Italy", City: "Rome"},
+			heading:         "Evening walks in Rome",
+			wantSpecificity: 0.4,
+		},
+		{
+			name:    "city within a word",
+			data:    service.HeadingData{Country: "Italy", City: "Rome"},
+			heading: "Evening walks of Romeo",
+		},
+		{
+			name:    "city name in lower case",
+			data:    service.HeadingData{Country: "France", City: "Nice"},
+			heading: "Have a nice evening walk",
+		},
+		{
+			name:            "weather at the start of the heading",
+			data:            service.HeadingData{Country: "France", City: "Nice", Weather: "sunny"},
+			heading:         "Sunny evening walks",
+			wantSpecificity: 0.1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scored := hg.Score(service.ArticleHeadings{tt.heading}, tt.data, shares, service.ScoreWeights{})
+			require.InDelta(t, tt.wantSpecificity, scored[0].Specificity, 1e-9)
+		})
+	}
+}
+
+func TestScore_Weights(t *testing.T) {
+	hg, err := service.NewHeadingGenerator(nil, 1)
+	require.NoError(t, err)
+
+	data := service.HeadingData{Country: "Italy", City: "Sorrento", Weather: "sunny"}
+	headings := service.ArticleHeadings{
+		"Sorrento",
+		"Wonderful break in sunny weather by the sea of Italy",
+	}
+
+	scored := hg.Score(headings, data, service.Shares{City: 1, Country: 1, Weather: 1},
+		service.ScoreWeights{Specificity: 1})
+	require.Equal(t, "Sorrento", scored[0].Heading)
+
+	scored = hg.Score(headings, data, service.Shares{City: 1, Country: 1, Weather: 1},
+		service.ScoreWeights{Length: 1})
+	require.Equal(t, "Wonderful break in sunny weather by the sea of Italy", scored[0].Heading)
+	require.Equal(t, 1.0, scored[0].Score)
+}
+
+func TestSelectHeadings(t *testing.T) {
+	scored := []service.ScoredHeading{
+		{Heading: "a", Score: 0.9},
+		{Heading: "b", Score: 0.7},
+		{Heading: "c", Score: 0.5},
+	}
+
+	tests := []struct {
+		name     string
+		top      int
+		minScore float64
+		want     service.ArticleHeadings
+	}{
+		{
+			name: "all",
+			want: service.ArticleHeadings{"a", "b", "c"},
+		},
+		{
+			name: "top",
+			top:  2,
+			want: service.ArticleHeadings{"a", "b"},
+		},
+		{
+			name:     "min score",
+			minScore: 0.6,
+			want:     service.ArticleHeadings{"a", "b"},
+		},
+		{
+			name:     "top and min score",
+			top:      1,
+			minScore: 0.6,
+			want:     service.ArticleHeadings{"a"},
+		},
+		{
+			name:     "none",
+			minScore: 0.95,
+			want:     service.ArticleHeadings{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, service.HeadingsOf(service.SelectHeadings(scored, tt.top, tt.minScore)))
+		})
+	}
+}
+
+func TestHeadingGenerator_Profile(t *testing.T) {
+	data := service.HeadingData{Country: "Italy", City: "Sorrento", Weekday: "Weekend", Month: "October"}
+	headings := service.ArticleHeadings{"Beautiful Weekend in Sorrento full of Restaurants"}
+
+	hg, err := service.NewHeadingGenerator(nil, 1)
+	require.NoError(t, err)
+	require.Nil(t, hg.Profile(headings, data, nil))
+
+	profiled := hg.Profile(headings, data, []profile.Profile{profile.OGTitle, profile.SEOTitle})
+	require.Len(t, profiled, 2)
+	require.Equal(t, "Beautiful weekend in Sorrento full of restaurants", profiled[0].Heading)
+	require.Equal(t, "Beautiful Weekend in Sorrento Full of Restaurants", profiled[1].Heading)
+	require.Equal(t, "beautiful-weekend-in-sorrento-full-of-restaurants", profiled[1].Slug)
+
+	// German nouns keep their capitalization.
+	de, err := locale.Load("de")
+	require.NoError(t, err)
+	hg, err = service.NewLocalizedHeadingGenerator(de, nil, 1)
+	require.NoError(t, err)
+	profiled = hg.Profile(service.ArticleHeadings{"Wunderschönes Wochenende in Sorrento voller Restaurants"},
+		data, []profile.Profile{profile.OGTitle})
+	require.Equal(t, "Wunderschönes Wochenende in Sorrento voller Restaurants", profiled[0].Heading)
+	require.True(t, profiled[0].Compliant)
+	require.Equal(t, "wunderschoenes-wochenende-in-sorrento-voller-restaurants", profiled[0].Slug)
+}
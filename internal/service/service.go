@@ -3,32 +3,91 @@ package service
 import (
 	"context"
 	"io/ioutil"
-	"log"
 	"math/rand"
-	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/tamarakaufler/travel-article-headings/internal/client"
 	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/holiday"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
 	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+	"github.com/tamarakaufler/travel-article-headings/internal/profile"
+	"github.com/tamarakaufler/travel-article-headings/internal/season"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
 )
 
 // ArticleHeadings ...
 type ArticleHeadings []string
 
+// ErrNoAdditionalInfo is returned when location, weather or places of interest data
+// could not be retrieved for any of the article photos.
+var ErrNoAdditionalInfo = errors.New("photo related data could not be retrieved")
+
 // Service interface prescribes methods that the service instance needs to implement.
 type Service interface {
 	GetArticles(ctx context.Context) ([]string, error)
-	Run(ctx context.Context)
 }
 
 // ArticleService encapsulates service clients.
 type ArticleService struct {
 	Clients client.Clients
 	Dir     string
+
+	// Generator creates the article headings. Default templates are used if not provided.
+	Generator *HeadingGenerator
+
+	// Retry determines retrying of failed requests. DefaultRetryPolicy is used if not provided.
+	Retry RetryPolicy
+
+	// Trip determines segmentation of the article photos into stops. trip.DefaultConfig
+	// is used if not provided.
+	Trip trip.Config
+
+	// Cluster determines density-based clustering of the photo positions. Photo locations
+	// are retrieved for the cluster centroids. trip.DefaultClusterConfig is used if not provided.
+	Cluster trip.ClusterConfig
+
+	// Weighting determines how much each photo counts in the article information rankings.
+	// Every photo counts once if not provided. MaxDwell caps the time spent near a photo
+	// (DefaultMaxDwell if not provided).
+	Weighting Weighting
+	MaxDwell  time.Duration
+
+	// Seasons resolves the photo seasons. The meteorological calendar is used if not provided.
+	Seasons season.Resolver
+	// Holidays provides the public holidays and festivals of the photo days. There are no holidays
+	// if not provided.
+	Holidays *holiday.Calendar
+
+	// Places determines the selection of the most distinctive places of interest mentioned by the headings.
+	// The places are ranked by their share if no baseline is provided and one place is mentioned
+	// if no number is provided.
+	Places Places
+
+	// Scoring weights the heading score components. DefaultScoreWeights are used if not provided.
+	// Only the Top (all if not provided) headings scoring at least MinScore are suggested.
+	Scoring  ScoreWeights
+	Top      int
+	MinScore float64
+
+	// Diversity determines the selection of diverse headings from the oversampled candidates.
+	// DefaultDiversity is used if not provided.
+	Diversity Diversity
+	// Phrases keeps the headings of the articles from reusing the same phrasing. The articles
+	// processed with the same phrase book should get different headings. Phrases are not remembered
+	// if not provided.
+	Phrases *PhraseBook
+
+	// Profiles fit the headings to the platforms they are published on, eg profile.SEOTitle.
+	Profiles []profile.Profile
+
+	// Tone is the editorial tone of the headings: the name of a builtin tone, eg family, or tone.Auto
+	// to infer the tone from the places of interest. The default vocabulary is used if not provided.
+	Tone string
 }
 
 // New is an ArticleService constructor.
@@ -37,106 +96,331 @@ func New(cfg conf.Setup, dir string) (ArticleService, error) {
 		dir = cfg.Directory
 	}
 
+	weighting, err := ParseWeighting(cfg.RankingWeighting)
+	if err != nil {
+		return ArticleService{}, err
+	}
+	seasons, err := seasonResolver(cfg)
+	if err != nil {
+		return ArticleService{}, err
+	}
+	holidays, err := holidayCalendar(cfg)
+	if err != nil {
+		return ArticleService{}, err
+	}
+	baseline, err := placesBaseline(cfg)
+	if err != nil {
+		return ArticleService{}, err
+	}
+	catalog, err := locale.Load(cfg.Locale)
+	if err != nil {
+		return ArticleService{}, err
+	}
+	profiles, err := profile.Parse(cfg.Profiles)
+	if err != nil {
+		return ArticleService{}, err
+	}
+	if err := tone.Validate(cfg.Tone); err != nil {
+		return ArticleService{}, err
+	}
+	hg, err := NewLocalizedHeadingGenerator(catalog, nil, time.Now().UnixNano())
+	if err != nil {
+		return ArticleService{}, err
+	}
+
 	cs, err := client.BuildClients(cfg)
 	if err != nil {
 		return ArticleService{}, err
 	}
 	return ArticleService{
-		Clients: cs,
-		Dir:     dir,
+		Clients:   cs,
+		Dir:       dir,
+		Generator: hg,
+		Trip: trip.Config{
+			MaxGap:      cfg.TripMaxGap,
+			MaxDistance: cfg.TripMaxDistance,
+		},
+		Cluster: trip.ClusterConfig{
+			Eps:       cfg.ClusterEps,
+			MinPoints: cfg.ClusterMinPoints,
+		},
+		Weighting: weighting,
+		MaxDwell:  cfg.RankingMaxDwell,
+		Seasons:   seasons,
+		Holidays:  holidays,
+		Places: Places{
+			Baseline:    baseline,
+			Distinctive: cfg.PlacesDistinctive,
+		},
+		Scoring: ScoreWeights{
+			Specificity: cfg.ScoreSpecificity,
+			Confidence:  cfg.ScoreConfidence,
+			Length:      cfg.ScoreLength,
+			Readability: cfg.ScoreReadability,
+			Redundancy:  cfg.ScoreRedundancy,
+		},
+		Top:      cfg.HeadingsTop,
+		MinScore: cfg.HeadingsMinScore,
+		Diversity: Diversity{
+			Oversample: cfg.DiversityOversample,
+			Lambda:     cfg.DiversityLambda,
+		},
+		Profiles: profiles,
+		Tone:     cfg.Tone,
 	}, nil
 }
 
+func seasonResolver(cfg conf.Setup) (season.Resolver, error) {
+	calendar, err := season.ParseCalendar(cfg.SeasonCalendar)
+	if err != nil {
+		return season.Resolver{}, err
+	}
+	r := season.Resolver{Calendar: calendar}
+	if calendar != season.Custom {
+		return r, nil
+	}
+
+	if cfg.SeasonTable == "" {
+		return season.Resolver{}, errors.New("custom season calendar requires a season table (SEASON_TABLE)")
+	}
+	r.Table, err = season.LoadTable(cfg.SeasonTable)
+	return r, err
+}
+
+// holidayCalendar is the bundled holiday calendar extended with the HOLIDAY_CALENDAR file.
+func holidayCalendar(cfg conf.Setup) (*holiday.Calendar, error) {
+	if cfg.HolidayCalendar == "" {
+		return holiday.Default(), nil
+	}
+
+	c, err := holiday.Load(cfg.HolidayCalendar)
+	if err != nil {
+		return nil, err
+	}
+	return holiday.Default().Extend(c), nil
+}
+
+// placesBaseline is the bundled places of interest baseline extended with the PLACES_BASELINE file.
+func placesBaseline(cfg conf.Setup) (*poi.Baseline, error) {
+	if cfg.PlacesBaseline == "" {
+		return poi.Default(), nil
+	}
+
+	b, err := poi.Load(cfg.PlacesBaseline)
+	if err != nil {
+		return nil, err
+	}
+	return poi.Default().Extend(b), nil
+}
+
 var _ Service = ArticleService{}
 
+// ProcessArticle collects additional information for the article photos and suggests
+// the article headings. It does not present the headings, but returns them together with
+// the summary of the information they are based on.
+func (as ArticleService) ProcessArticle(ctx context.Context, name string, photoL []photo.Data,
+) (Article, error) {
+	return as.ProcessArticleWithEvents(ctx, name, photoL, nil)
+}
+
 //nolint:funlen
-// Run processes the supplied csv files with photo date and lat/lon information
-// and creates a list of heading suggestions for each file/article.
-func (as ArticleService) Run(ctx context.Context) {
-	albs, err := as.GetArticles(ctx)
-	if err != nil {
-		log.Fatalf(errors.Wrapf(err, "failure to get articles").Error())
+// ProcessArticleWithEvents is ProcessArticle, that also reports the additional photo
+// information, or the failure to retrieve it, as soon as it is collected.
+// The events are reported sequentially. A nil notify reports nothing.
+func (as ArticleService) ProcessArticleWithEvents(ctx context.Context, name string, photoL []photo.Data,
+	notify func(Event),
+) (Article, error) {
+	chans, syncs := as.MakeChannelsAndSyncs([]string{name})
+	ch, wgS := chans[name], syncs[name]
+
+	article := Article{
+		Name:      name,
+		PhotoData: photoL,
+		Summary: Summary{
+			Photos: len(photoL),
+		},
 	}
-	albPaths := []string{}
-	for _, alb := range albs {
-		albPaths = append(albPaths, filepath.Join(as.Dir, alb))
-	}
-	chans, syncs := as.MakeChannelsAndSyncs(albPaths)
-
-	doneCh := make(chan string)
-
-	// listen and act
-	go func(ctx context.Context, chans photo.Channels, done chan string) {
-		for {
-			for _, albP := range albPaths {
-				select {
-				case errM := <-chans[albP].Error:
-					log.Printf("ERROR %s\n", errM)
-				case <-chans[albP].Cancel:
-					log.Print("unrecoverable error: cancelling request")
-					os.Exit(1)
-				case doneM := <-doneCh:
-					log.Printf("%s\n", doneM)
-					return
-				default:
-				}
+
+	notify = sequential(notify)
+	collectCh := relayWithEvents(ch, notify)
+
+	// an unrecoverable error cancels the remaining requests.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// collect errors reported by the clients.
+	var abortErr error
+	errDone := make(chan struct{})
+	go func() {
+		defer close(errDone)
+
+		for err := range collectCh.Error {
+			if abortErr != nil && ctx.Err() != nil && errors.Is(err, context.Canceled) {
+				continue
+			}
+			article.Summary.Errors = append(article.Summary.Errors, err.Error())
+			notify(Event{Article: name, Error: err})
+
+			if abortErr == nil && ClassifyError(ctx, err) == Abort {
+				abortErr = err
+				cancel()
 			}
 		}
-	}(ctx, chans, doneCh)
+	}()
 
-	as.retrieveAdditionalData(ctx, albPaths, chans, syncs)
-	ingestAdditionalInfoAndSuggestHeadings(ctx, albPaths, chans, syncs)
+	as.CollectAdditionalInfo(ctx, collectCh, wgS, photoL)
+	go closeWhenCollected(wgS, collectCh)
 
-	// wait for additional photo info retrieval and processing.
-	wgPF := &sync.WaitGroup{}
+	articleLocationMap, articleWeatherMap, articlePoiMap := IngestAndProcess(ctx, name, ch)
 
-	// 	locations
-	for _, albP := range albPaths {
-		wgPF.Add(1)
-		go func(wgPF *sync.WaitGroup, wgS *photo.WgSync, chanL chan photo.LocationM) {
-			defer wgPF.Done()
+	// all clients are done once the data channels are closed.
+	close(collectCh.Error)
+	<-errDone
 
-			wgS.Location.Wait()
-			close(chanL)
-		}(wgPF, syncs[albP], chans[albP].Location)
+	article.Summary.Locations = len(articleLocationMap)
+	article.Summary.Weathers = len(articleWeatherMap)
+	article.Summary.Pois = len(articlePoiMap)
+
+	if abortErr != nil {
+		return article, errors.Wrap(abortErr, "article processing aborted")
+	}
+	if len(articleLocationMap) <= 0 || len(articleWeatherMap) <= 0 || len(articlePoiMap) <= 0 {
+		return article, ErrNoAdditionalInfo
 	}
 
-	// 	weather
-	for _, albP := range albPaths {
-		wgPF.Add(1)
-		go func(wgPF *sync.WaitGroup, wgS *photo.WgSync, chanW chan photo.WeatherM) {
-			defer wgPF.Done()
+	data, err := as.summarize(&article.Summary, photoL, articleLocationMap, articleWeatherMap, articlePoiMap)
+	if err != nil {
+		return article, err
+	}
 
-			wgS.Weather.Wait()
-			close(chanW)
-		}(wgPF, syncs[albP], chans[albP].Weather)
+	hg := as.Generator
+	if hg == nil {
+		hg = defaultHeadingGenerator()
+	}
+	scored, err := hg.Suggest(data, article.Summary.Shares, as.Scoring, as.Diversity, as.Top, as.MinScore, as.Phrases)
+	if err != nil {
+		return article, err
 	}
+	article.Scores = scored
+	article.Headings = HeadingsOf(scored)
+	article.Profiled = hg.Profile(article.Headings, data, as.Profiles)
 
-	// 	poi
-	for _, albP := range albPaths {
-		wgPF.Add(1)
-		go func(wgPF *sync.WaitGroup, wgS *photo.WgSync, chanP chan photo.PoiM) {
-			defer wgPF.Done()
+	return article, nil
+}
 
-			wgS.Poi.Wait()
-			close(chanP)
-		}(wgPF, syncs[albP], chans[albP].Poi)
+// summarize fills in the summary with the top article information and provides the heading data
+// in the article tone. The place of interest is the top one preferred by the tone, if any.
+func (as ArticleService) summarize(summary *Summary, photoL []photo.Data,
+	articleLocationMap []photo.LocationM, articleWeatherMap []photo.WeatherM, articlePoiMap []photo.PoiM,
+) (HeadingData, error) {
+	w := NewWeights(photoL, as.Weighting, as.MaxDwell)
+	data := w.HeadingData(articleLocationMap, articleWeatherMap, articlePoiMap)
+
+	summary.Country, summary.City, _ = w.TopLocation(articleLocationMap)
+	clusters, _ := as.clusters(photoL)
+	if c, ok := trip.Dominant(clusters); ok {
+		summary.Focus = &Area{Latitude: c.Lat, Longitude: c.Lon, Spread: c.Spread}
+		if focus, located := clusterLocation(c, articleLocationMap); located {
+			summary.Country, summary.City = focus.Country, focus.City
+			data.Country, data.City = focus.Country, focus.City
+		}
+	}
+	summary.Weather = w.TopWeather(articleWeatherMap)
+	summary.Weekday, summary.Month, summary.Season = w.TopTimeInfo(articleWeatherMap)
+	summary.TimeOfDay = w.TimeOfDayRanking(articleWeatherMap).First().Name
+	summary.Holiday = w.HolidayRanking(articleWeatherMap).First().Name
+	pois := w.DistinctivePlacesRanking(articlePoiMap, as.Places.Baseline, countryCode(articleLocationMap, summary.Country))
+	t, err := as.tone(pois)
+	if err != nil {
+		return HeadingData{}, err
 	}
-	wgPF.Wait()
+	summary.Tone = t.Name
+	summary.PlacesOfInterest = as.Places.distinctive(pois, t)
+	summary.PlaceOfInterest = first(summary.PlacesOfInterest)
+	data.PlaceOfInterest, data.PlacesOfInterest = summary.PlaceOfInterest, summary.PlacesOfInterest
+	data = data.WithTone(t)
+	landmarks := w.LandmarkRanking(articlePoiMap)
+	summary.Landmark = landmark(landmarks)
+	data.Landmark = summary.Landmark
+	summary.Shares = w.shares(*summary, articleLocationMap, articleWeatherMap, articlePoiMap)
+	summary.Shares.Landmark = landmarks.ShareOf(summary.Landmark)
+
+	it := trip.Segment(trip.Points(photoL), as.Trip).Locate(articleLocationMap)
+	summary.Itinerary = it.Cities()
+	summary.Region = it.Region()
+	data.Itinerary, data.Region = summary.Itinerary, summary.Region
+
+	span := trip.Classify(trip.Days(photoL))
+	summary.Duration, summary.Days = string(span.Duration), span.Days
+	data.Duration, data.Days = summary.Duration, summary.Days
+
+	return data, nil
+}
 
-	// wait for heading creation and presentation to finish.
-	wgTF := &sync.WaitGroup{}
-	for _, albP := range albPaths {
-		wgTF.Add(1)
-		go func(wgTF *sync.WaitGroup, wgS *photo.WgSync) {
-			defer wgTF.Done()
+// tone provides the article tone, inferred from the places of interest if the service Tone is tone.Auto.
+// There is no tone if none is set or inferred.
+func (as ArticleService) tone(pois Ranking) (tone.Tone, error) {
+	switch as.Tone {
+	case "":
+		return tone.Tone{}, nil
+	case tone.Auto:
+		shares := map[string]float64{}
+		for _, p := range pois {
+			shares[p.Name] = p.Share
+		}
+		t, _ := tone.Infer(shares)
+		return t, nil
+	default:
+		return tone.Lookup(as.Tone)
+	}
+}
 
-			wgS.Heading.Wait()
-		}(wgTF, syncs[albP])
+// countryCode is the code of the country of the located photos.
+func countryCode(locations []photo.LocationM, country string) string {
+	for _, l := range locations {
+		if l.Location.Country == country && l.Location.CountryCode != "" {
+			return l.Location.CountryCode
+		}
+	}
+	return ""
+}
+
+func first(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+// clusters clusters the photo positions within each trip stop, so that the stops are not merged.
+func (as ArticleService) clusters(photoL []photo.Data) ([]trip.Cluster, []int) {
+	return trip.ClusterStops(trip.Positions(photoL), trip.Segment(trip.Points(photoL), as.Trip), as.Cluster)
+}
+
+// clusterLocation is the location of the cluster photos. Bursts of photos taken in one
+// spot and stray photos do not outweigh the dominant cluster this way.
+func clusterLocation(c trip.Cluster, locations []photo.LocationM) (photo.Location, bool) {
+	for _, lm := range locations {
+		for _, id := range c.PhotoIDs {
+			if lm.PhotoID == id && lm.Location.City != "" {
+				return lm.Location, true
+			}
+		}
 	}
-	wgTF.Wait()
+	return photo.Location{}, false
+}
+
+// closeWhenCollected closes the article data channels when all the additional photo info
+// has been sent.
+func closeWhenCollected(wgS *photo.WgSync, chans photo.Channel) {
+	wgS.Location.Wait()
+	close(chans.Location)
+
+	wgS.Weather.Wait()
+	close(chans.Weather)
 
-	doneCh <- "FINISHED 🎉"
+	wgS.Poi.Wait()
+	close(chans.Poi)
 }
 
 // GetArticles provides a list of csv file paths.
@@ -148,6 +432,9 @@ func (as ArticleService) GetArticles(ctx context.Context) ([]string, error) {
 
 	csv := []string{}
 	for _, f := range files {
+		if tone.IsSidecar(f.Name()) {
+			continue
+		}
 		csv = append(csv, f.Name())
 	}
 
@@ -167,7 +454,7 @@ func (as ArticleService) MakeChannelsAndSyncs(albs []string) (photo.Channels, ph
 			Poi:      make(chan photo.PoiM),
 
 			Cancel: make(chan struct{}),
-			Error:  make(chan string),
+			Error:  make(chan error),
 		}
 		chans[alb] = ch
 
@@ -186,30 +473,24 @@ func (as ArticleService) MakeChannelsAndSyncs(albs []string) (photo.Channels, ph
 	return chans, syncs
 }
 
-func (as ArticleService) retrieveAdditionalData(ctx context.Context,
-	albPaths []string, chans photo.Channels, syncs photo.WgSyncs) {
-	for _, alb := range albPaths {
-		photoL, err := ReadPhotoData(ctx, alb)
-		if err != nil {
-			log.Fatalf("failure to get photos %s", err)
-		}
-
-		// retrieval of location/weather/poi information.
-		as.CollectAdditionalInfo(ctx, chans[alb], syncs[alb], photoL)
-	}
-}
-
 // CollectAdditionalInfo retrieves additional photo info using 3rd party services.
+// The photo time information is resolved once the photo is located, in the timezone
+// of the located country.
 func (as ArticleService) CollectAdditionalInfo(ctx context.Context,
 	chans photo.Channel, wgS *photo.WgSync, photoL []photo.Data,
 ) {
+	located := newLocations(photoL)
+
 	// retrieve location data for article photos.
 	wgS.Location.Add(1)
 	go func(ctx context.Context, wg *sync.WaitGroup, chans photo.Channel, photoL []photo.Data) {
 		defer wg.Done()
 
 		wgL := &sync.WaitGroup{}
-		for _, pd := range photoL {
+		for _, lr := range as.locationRequests(photoL) {
+			if ctx.Err() != nil {
+				break
+			}
 			wgL.Add(1)
 
 			// Introducing backoff between goroutines to avoid HTTP 429/too many requests error/3rd party
@@ -219,11 +500,11 @@ func (as ArticleService) CollectAdditionalInfo(ctx context.Context,
 			// concurrency.
 			generateSleep(50, 180)
 
-			go func(ctx context.Context, wgL *sync.WaitGroup, pd photo.Data) {
+			go func(ctx context.Context, wgL *sync.WaitGroup, lr locationRequest) {
 				defer wgL.Done()
 
-				as.Clients.Addresses.EnhanceWithLocation(ctx, chans, pd)
-			}(ctx, wgL, pd)
+				as.enhanceWithLocation(ctx, chans, lr, located)
+			}(ctx, wgL, lr)
 		}
 		wgL.Wait()
 	}(ctx, wgS.Location, chans, photoL)
@@ -239,7 +520,7 @@ func (as ArticleService) CollectAdditionalInfo(ctx context.Context,
 			go func(ctx context.Context, wgW *sync.WaitGroup, pd photo.Data) {
 				defer wgW.Done()
 
-				as.Clients.Weather.EnhanceWithWeather(ctx, chans, pd)
+				as.enhanceWithWeather(ctx, chans, pd, located)
 			}(ctx, wgW, pd)
 		}
 		wgW.Wait()
@@ -256,38 +537,183 @@ func (as ArticleService) CollectAdditionalInfo(ctx context.Context,
 			go func(ctx context.Context, wgP *sync.WaitGroup, pd photo.Data) {
 				defer wgP.Done()
 
-				as.Clients.POI.EnhanceWithPlacesOfInterest(ctx, chans, pd)
+				as.enhanceWithPlacesOfInterest(ctx, chans, pd)
 			}(ctx, wgP, pd)
 		}
 		wgP.Wait()
 	}(ctx, wgS.Poi, chans, photoL)
 }
 
-func ingestAdditionalInfoAndSuggestHeadings(ctx context.Context,
-	albPaths []string, chans photo.Channels, syncs photo.WgSyncs,
+// locationRequest is a photo location retrieval on behalf of the photos.
+type locationRequest struct {
+	pd     photo.Data
+	photos []photo.Data
+}
+
+// locationRequests clusters the photo positions and requests the location of each cluster
+// medoid photo once, on behalf of all the cluster photos. The other photos, ie the outliers
+// and the photos without a valid position (to report the failure), are located one by one.
+func (as ArticleService) locationRequests(photoL []photo.Data) []locationRequest {
+	clusters, _ := as.clusters(photoL)
+
+	byID := map[int]photo.Data{}
+	for _, pd := range photoL {
+		byID[pd.ID] = pd
+	}
+
+	lrs := []locationRequest{}
+	clustered := map[int]bool{}
+	for _, c := range clusters {
+		lr := locationRequest{pd: byID[c.Medoid]}
+		for _, id := range c.PhotoIDs {
+			lr.photos = append(lr.photos, byID[id])
+			clustered[id] = true
+		}
+		lrs = append(lrs, lr)
+	}
+
+	for _, pd := range photoL {
+		if clustered[pd.ID] {
+			continue
+		}
+		lrs = append(lrs, locationRequest{pd: pd, photos: []photo.Data{pd}})
+	}
+
+	return lrs
+}
+
+// locations of the article photos, available to the other photo information requests
+// as soon as the photos are located (or fail to be).
+type locations struct {
+	mu      *sync.Mutex
+	located map[int]photo.Location
+	// done is closed once the photo location request is over.
+	done map[int]chan struct{}
+}
+
+func newLocations(photoL []photo.Data) locations {
+	ls := locations{
+		mu:      &sync.Mutex{},
+		located: map[int]photo.Location{},
+		done:    map[int]chan struct{}{},
+	}
+	for _, pd := range photoL {
+		ls.done[pd.ID] = make(chan struct{})
+	}
+	return ls
+}
+
+// set records the location of the photo, the zero location if it was not located.
+func (ls locations) set(id int, l photo.Location) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	done, ok := ls.done[id]
+	if !ok {
+		return
+	}
+	select {
+	case <-done:
+	default:
+		ls.located[id] = l
+		close(done)
+	}
+}
+
+// wait provides the location of the photo once its location request is over or the context is done.
+func (ls locations) wait(ctx context.Context, id int) photo.Location {
+	select {
+	case <-ls.done[id]:
+	case <-ctx.Done():
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.located[id]
+}
+
+// enhanceWithLocation sends the location of the request photos, or the failure to retrieve it,
+// to the article channels.
+func (as ArticleService) enhanceWithLocation(ctx context.Context, chans photo.Channel, lr locationRequest,
+	located locations,
 ) {
-	for _, albP := range albPaths {
-		wgT := syncs[albP].Heading
-		chans := chans[albP]
+	var l photo.Location
+	err := as.Retry.withRetry(ctx, func() (err error) {
+		l, err = as.Clients.Addresses.Locate(ctx, lr.pd)
+		return err
+	})
+
+	for _, pd := range lr.photos {
+		located.set(pd.ID, l)
+	}
+	for _, pd := range lr.photos {
+		if err != nil {
+			chans.Error <- &PhotoError{ArticleID: pd.ArticleID, PhotoID: pd.ID, Info: LocationInfo, Err: err}
+			continue
+		}
 
-		wgT.Add(1)
-		go func(ctx context.Context, wgT *sync.WaitGroup, chans photo.Channel) {
-			defer wgT.Done()
+		chans.Location <- photo.LocationM{
+			ArticleID: pd.ArticleID,
+			PhotoID:   pd.ID,
+			Location:  l,
+		}
+	}
+}
 
-			articleLocationMap, articleWeatherMap, articlePoiMap := IngestAndProcess(ctx, chans.Article, chans)
+// enhanceWithWeather sends the weather and time information of the photo, or the failure
+// to retrieve it, to the article channels. The time information is resolved in the timezone,
+// season calendar and holiday calendar of the photo country, once the photo is located.
+func (as ArticleService) enhanceWithWeather(ctx context.Context, chans photo.Channel, pd photo.Data,
+	located locations,
+) {
+	var w string
+	err := as.Retry.withRetry(ctx, func() (err error) {
+		w, err = as.Clients.Weather.Weather(ctx, pd)
+		return err
+	})
+	if err != nil {
+		chans.Error <- &PhotoError{ArticleID: pd.ArticleID, PhotoID: pd.ID, Info: WeatherInfo, Err: err}
+		return
+	}
 
-			if len(articleLocationMap) <= 0 || len(articleWeatherMap) <= 0 || len(articlePoiMap) <= 0 {
-				log.Print("Photo related data could not be retrieved.\nNo heading suggestions could be made.\n")
-				log.Printf("locations: %d, weather data: %d, poi %d\n\n",
-					len(articleLocationMap), len(articleWeatherMap), len(articlePoiMap))
-				return
-			}
+	wm := photo.WeatherM{
+		ArticleID: pd.ArticleID,
+		PhotoID:   pd.ID,
+		Weather:   w,
+	}
+	ti, err := client.LocalTimeInfoWith(pd, located.wait(ctx, pd.ID), as.Seasons, as.Holidays)
+	if err == nil {
+		wm.TimeInfo = ti
+	}
+
+	chans.Weather <- wm
+}
+
+// enhanceWithPlacesOfInterest sends the places of interest near the photo, with the named landmarks
+// if the client provides them, or the failure to retrieve them, to the article channels.
+func (as ArticleService) enhanceWithPlacesOfInterest(ctx context.Context, chans photo.Channel, pd photo.Data) {
+	var (
+		places    map[string]int
+		landmarks []photo.Landmark
+	)
+	err := as.Retry.withRetry(ctx, func() (err error) {
+		if lp, ok := as.Clients.POI.(client.LandmarkPoi); ok {
+			places, landmarks, err = lp.PlacesAndLandmarks(ctx, pd)
+			return err
+		}
+		places, err = as.Clients.POI.PlacesOfInterest(ctx, pd)
+		return err
+	})
+	if err != nil {
+		chans.Error <- &PhotoError{ArticleID: pd.ArticleID, PhotoID: pd.ID, Info: PoiInfo, Err: err}
+		return
+	}
 
-			headings := CreateArticleHeadings(ctx,
-				articleLocationMap, articleWeatherMap, articlePoiMap,
-			)
-			PresentSuggestedHeadings(chans.Article, headings)
-		}(ctx, wgT, chans)
+	chans.Poi <- photo.PoiM{
+		ArticleID: pd.ArticleID,
+		PhotoID:   pd.ID,
+		POI:       places,
+		Landmarks: landmarks,
 	}
 }
 
@@ -313,7 +739,7 @@ func IngestAndProcess(ctx context.Context, alb string, chans photo.Channel,
 }
 
 func generateSleep(n, i int) {
-	rand.Seed(time.Now().UnixNano())
-	r := rand.Intn(n) + i
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+	r := rnd.Intn(n) + i
 	time.Sleep(time.Duration(r) * time.Millisecond) // to avoid HTTP 429, too many requests
 }
@@ -4,6 +4,7 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -20,6 +21,12 @@ type weathClientM struct {
 }
 type poiClientM struct {
 }
+type failingAddrClientM struct {
+}
+type franceAddrClientM struct {
+}
+
+var errLocation = errors.New("location not found")
 
 func Test_CollectAdditionalInfo(t *testing.T) {
 	// prepare test data.
@@ -114,6 +121,103 @@ LOOP:
 	require.Equal(t, msgL, poiCount)
 }
 
+func Test_CollectAdditionalInfo_Errors(t *testing.T) {
+	ctx := context.Background()
+	photoL := []photo.Data{
+		{
+			ArticleID: "article1",
+			ID:        1,
+			Date:      "2019-11-25T22:37:44Z",
+			LatLon: photo.LatLon{
+				Latitude:  "36.111111",
+				Longitude: "16.11111",
+			},
+		},
+	}
+	as, ch, s := setup("article1")
+	as.Clients.Addresses = failingAddrClientM{}
+
+	as.CollectAdditionalInfo(ctx, ch, s, photoL)
+
+	timer := time.After(3 * time.Second)
+	var err error
+LOOP:
+	for {
+		select {
+		case <-timer:
+			t.Fatal("Test_CollectAdditionalInfo_Errors timed out")
+		case err = <-ch.Error:
+			break LOOP
+		case <-ch.Weather:
+		case <-ch.Poi:
+		}
+	}
+
+	require.True(t, errors.Is(err, errLocation))
+
+	photoErr := &service.PhotoError{}
+	require.True(t, errors.As(err, &photoErr))
+	require.Equal(t, 1, photoErr.PhotoID)
+	require.Equal(t, service.LocationInfo, photoErr.Info)
+}
+
+func Test_CollectAdditionalInfo_LocalTime(t *testing.T) {
+	ctx := context.Background()
+	// Paris is not within the bundled timezone boundaries, its timezone is that of the located country.
+	photoL := []photo.Data{
+		{
+			ArticleID: "article1",
+			ID:        1,
+			Date:      "2021-07-10T16:30:00Z",
+			LatLon: photo.LatLon{
+				Latitude:  "48.8566",
+				Longitude: "2.3522",
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		addresses     client.Addresses
+		wantTimeOfDay string
+	}{
+		{
+			name:          "located in France",
+			addresses:     franceAddrClientM{},
+			wantTimeOfDay: "Evening",
+		},
+		{
+			name:          "not located",
+			addresses:     failingAddrClientM{},
+			wantTimeOfDay: "Afternoon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as, ch, s := setup("article1")
+			as.Clients.Addresses = tt.addresses
+
+			as.CollectAdditionalInfo(ctx, ch, s, photoL)
+
+			timer := time.After(3 * time.Second)
+			for {
+				select {
+				case <-timer:
+					t.Fatal("Test_CollectAdditionalInfo_LocalTime timed out")
+				case <-ch.Location:
+				case <-ch.Error:
+				case <-ch.Poi:
+				case wm := <-ch.Weather:
+					require.Equal(t, "Saturday", wm.TimeInfo.Weekday)
+					require.Equal(t, tt.wantTimeOfDay, wm.TimeInfo.TimeOfDay)
+					return
+				}
+			}
+		})
+	}
+}
+
 func setup(alb string) (service.ArticleService, photo.Channel, *photo.WgSync) {
 	ac := addrClientM{}
 	wc := weathClientM{}
@@ -143,7 +247,7 @@ func createArticleChannel(alb string) photo.Channel {
 		Poi:      make(chan photo.PoiM),
 
 		Cancel: make(chan struct{}),
-		Error:  make(chan string),
+		Error:  make(chan error),
 	}
 }
 
@@ -159,35 +263,33 @@ func createSync(alb string) *photo.WgSync {
 	}
 }
 
-func (ac addrClientM) EnhanceWithLocation(ctx context.Context, chans photo.Channel, pd photo.Data) {
-	chans.Location <- photo.LocationM{
-		ArticleID: pd.ArticleID,
-		PhotoID:   pd.ID,
-		Location: photo.Location{
-			Country: "Czechia",
-			City:    "Prague",
-		},
-	}
+func (ac addrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{
+		Country: "Czechia",
+		City:    "Prague",
+	}, nil
 }
-func (wc weathClientM) EnhanceWithWeather(ctx context.Context, chans photo.Channel, pd photo.Data,
-) {
-	chans.Weather <- photo.WeatherM{
-		ArticleID: pd.ArticleID,
-		PhotoID:   pd.ID,
-		Weather:   "sunny",
-	}
+
+func (ac failingAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{}, errLocation
+}
+
+func (ac franceAddrClientM) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	return photo.Location{
+		Country:     "France",
+		CountryCode: "FRA",
+		City:        "Paris",
+	}, nil
 }
 
-func (pc poiClientM) EnhanceWithPlacesOfInterest(ctx context.Context, chans photo.Channel, pd photo.Data,
-) {
-	places := map[string]int{
+func (wc weathClientM) Weather(ctx context.Context, pd photo.Data) (string, error) {
+	return "sunny", nil
+}
+
+func (pc poiClientM) PlacesOfInterest(ctx context.Context, pd photo.Data) (map[string]int, error) {
+	return map[string]int{
 		"Cinemas":     5,
 		"Restaurants": 10,
 		"Cafes":       15,
-	}
-	chans.Poi <- photo.PoiM{
-		ArticleID: pd.ArticleID,
-		PhotoID:   pd.ID,
-		POI:       places,
-	}
+	}, nil
 }
@@ -0,0 +1,72 @@
+// +build service_tests
+
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+func TestProcessArticle_Tone(t *testing.T) {
+	photoL := []photo.Data{
+		{ArticleID: "article1", ID: 1, Date: "2019-10-27T13:27:58Z",
+			LatLon: photo.LatLon{Latitude: "50.087", Longitude: "14.421"}},
+		{ArticleID: "article1", ID: 2, Date: "2019-10-27T14:27:58Z",
+			LatLon: photo.LatLon{Latitude: "50.088", Longitude: "14.422"}},
+	}
+
+	// the places of interest are Cafes (1/2), Restaurants (1/3) and Cinemas (1/6).
+	tests := []struct {
+		name  string
+		tone  string
+		want  string
+		place string
+		err   string
+	}{
+		{
+			name:  "no tone",
+			place: "Cafes",
+		},
+		{
+			name:  "inferred",
+			tone:  "auto",
+			want:  "romantic",
+			place: "Cafes",
+		},
+		{
+			name:  "preferred place of interest",
+			tone:  "nightlife",
+			want:  "nightlife",
+			place: "Cinemas",
+		},
+		{
+			name: "unknown",
+			tone: "gloomy",
+			err:  `unknown tone "gloomy"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			as, _, _ := setup("article1")
+			as.Tone = tt.tone
+
+			a, err := as.ProcessArticle(context.Background(), "article1", photoL)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, a.Summary.Tone)
+			require.Equal(t, tt.place, a.Summary.PlaceOfInterest)
+			require.Greater(t, a.Summary.Shares.PlaceOfInterest, 0.0)
+			require.Contains(t, strings.Join(a.Headings, "\n"), strings.ToLower(tt.place))
+		})
+	}
+}
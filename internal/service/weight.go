@@ -0,0 +1,290 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+)
+
+// Weighting determines how much each photo counts when ranking the article information.
+type Weighting string
+
+// Weighting strategies.
+const (
+	// CountWeighting counts every photo once.
+	CountWeighting Weighting = "count"
+	// DwellWeighting weights photos by the time spent near them, so that many photos
+	// taken within minutes do not outweigh a whole day with few photos.
+	DwellWeighting Weighting = "dwell"
+	// HybridWeighting is the mean of the count and dwell weights.
+	HybridWeighting Weighting = "hybrid"
+)
+
+// DefaultMaxDwell caps the time spent near a photo.
+const DefaultMaxDwell = 2 * time.Hour
+
+// ParseWeighting validates the weighting strategy. An empty strategy means CountWeighting.
+func ParseWeighting(s string) (Weighting, error) {
+	switch w := Weighting(s); w {
+	case "":
+		return CountWeighting, nil
+	case CountWeighting, DwellWeighting, HybridWeighting:
+		return w, nil
+	default:
+		return "", errors.Errorf("unknown weighting %q: expected count, dwell or hybrid", s)
+	}
+}
+
+// Weights holds the photo weights by photo ID. Photos without a weight count once,
+// so nil Weights count every photo once.
+type Weights map[int]float64
+
+// NewWeights weights the photos with the weighting strategy.
+//
+// The dwell time of a photo is half of the time since the previous photo plus half
+// of the time until the next photo, each gap capped at maxDwell. Dwell weights are
+// relative to the mean dwell time, so that they add up to the number of photos
+// like the counts do. Photos with an invalid date count once.
+func NewWeights(photoL []photo.Data, w Weighting, maxDwell time.Duration) Weights {
+	if w == CountWeighting || w == "" {
+		return nil
+	}
+	if maxDwell <= 0 {
+		maxDwell = DefaultMaxDwell
+	}
+
+	type dated struct {
+		id int
+		t  time.Time
+	}
+	ds := []dated{}
+	for _, pd := range photoL {
+		t, err := photo.ParseDate(pd.Date)
+		if err != nil {
+			continue
+		}
+		ds = append(ds, dated{id: pd.ID, t: t})
+	}
+	sort.SliceStable(ds, func(i, j int) bool {
+		return ds[i].t.Before(ds[j].t)
+	})
+
+	gap := func(i, j int) time.Duration {
+		if i < 0 || j >= len(ds) {
+			return 0
+		}
+		if g := ds[j].t.Sub(ds[i].t); g < maxDwell {
+			return g
+		}
+		return maxDwell
+	}
+
+	dwell := map[int]time.Duration{}
+	total := time.Duration(0)
+	for i, d := range ds {
+		dw := (gap(i-1, i) + gap(i, i+1)) / 2
+		dwell[d.id] = dw
+		total += dw
+	}
+	if total <= 0 {
+		// all photos taken at the same time.
+		return nil
+	}
+	mean := float64(total) / float64(len(ds))
+
+	weights := Weights{}
+	for id, dw := range dwell {
+		weight := float64(dw) / mean
+		if w == HybridWeighting {
+			weight = (1 + weight) / 2
+		}
+		weights[id] = weight
+	}
+
+	return weights
+}
+
+// Of provides the photo weight.
+func (w Weights) Of(photoID int) float64 {
+	if weight, ok := w[photoID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// LocationRanking ranks the photo countries and cities.
+func (w Weights) LocationRanking(articleLocation []photo.LocationM) (Ranking, Ranking) {
+	countryM := map[string]float64{}
+	cityM := map[string]float64{}
+	for _, l := range articleLocation {
+		countryM[l.Location.Country] += w.Of(l.PhotoID)
+		cityM[l.Location.City] += w.Of(l.PhotoID)
+	}
+
+	return Rank(countryM), Rank(cityM)
+}
+
+// WeatherRanking ranks the photo weather.
+func (w Weights) WeatherRanking(weatherData []photo.WeatherM) Ranking {
+	weather := map[string]float64{}
+	for _, wd := range weatherData {
+		weather[wd.Weather] += w.Of(wd.PhotoID)
+	}
+
+	return Rank(weather)
+}
+
+// TimeInfoRanking ranks the photo weekdays, months and seasons.
+func (w Weights) TimeInfoRanking(weatherData []photo.WeatherM) (Ranking, Ranking, Ranking) {
+	weekday := map[string]float64{}
+	month := map[string]float64{}
+	season := map[string]float64{}
+	for _, wd := range weatherData {
+		ti := wd.TimeInfo
+		weekday[ti.Weekday] += w.Of(wd.PhotoID)
+		month[ti.Month] += w.Of(wd.PhotoID)
+		season[ti.Season] += w.Of(wd.PhotoID)
+	}
+
+	return Rank(weekday), Rank(month), Rank(season)
+}
+
+// TimeOfDayRanking ranks the local times of day of the photos.
+func (w Weights) TimeOfDayRanking(weatherData []photo.WeatherM) Ranking {
+	timeOfDay := map[string]float64{}
+	for _, wd := range weatherData {
+		timeOfDay[wd.TimeInfo.TimeOfDay] += w.Of(wd.PhotoID)
+	}
+
+	return Rank(timeOfDay)
+}
+
+// HolidayRanking ranks the holidays the photos were taken on. Photos taken on no holiday
+// are ranked with an empty name, so a holiday only comes first if it outweighs them.
+func (w Weights) HolidayRanking(weatherData []photo.WeatherM) Ranking {
+	holidays := map[string]float64{}
+	for _, wd := range weatherData {
+		if len(wd.TimeInfo.Holidays) == 0 {
+			holidays[""] += w.Of(wd.PhotoID)
+		}
+		for _, h := range wd.TimeInfo.Holidays {
+			holidays[h] += w.Of(wd.PhotoID)
+		}
+	}
+
+	return Rank(holidays)
+}
+
+// PlaceOfInterestRanking ranks the places of interest near the photos.
+func (w Weights) PlaceOfInterestRanking(poiData []photo.PoiM) Ranking {
+	places := map[string]float64{}
+	for _, p := range poiData {
+		for k, v := range p.POI {
+			places[k] += float64(v) * w.Of(p.PhotoID)
+		}
+	}
+
+	return Rank(places)
+}
+
+// DistinctivePlacesRanking ranks the places of interest near the photos by their distinctiveness in the country
+// (ISO 3166-1 alpha-3 code) compared with the baseline. The Weight of the ranked places is their distinctiveness
+// and the Share is that of all the places near the photos. A nil baseline ranks the places by their share.
+func (w Weights) DistinctivePlacesRanking(poiData []photo.PoiM, b *poi.Baseline, country string) Ranking {
+	r := w.PlaceOfInterestRanking(poiData)
+	for i := range r {
+		r[i].Weight = b.Distinctiveness(country, r[i].Name, r[i].Share)
+	}
+	// places as distinctive keep their ranking by weight.
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].Weight > r[j].Weight
+	})
+
+	return r
+}
+
+// LandmarkRanking ranks the named landmarks near the photos. Each photo taken near a landmark counts
+// by its weight and closeness to the landmark, and the landmark count is scaled up by its popularity.
+// The Share of the ranked landmarks is that of the photos (by weight) taken near them, so the landmarks
+// recurring near many photos can be told from those near a few.
+func (w Weights) LandmarkRanking(poiData []photo.PoiM) Ranking {
+	landmarks := map[string]float64{}
+	near := map[string]float64{}
+	total := 0.0
+	for _, p := range poiData {
+		total += w.Of(p.PhotoID)
+
+		// the nearest of the landmarks of the same name.
+		nearest := map[string]photo.Landmark{}
+		for _, l := range p.Landmarks {
+			if n, ok := nearest[l.Name]; !ok || l.Distance < n.Distance {
+				nearest[l.Name] = l
+			}
+		}
+		for name, l := range nearest {
+			closeness := 1 / (1 + math.Max(l.Distance, 0)/landmarkDistance)
+			popularity := 1 + math.Log10(1+math.Max(float64(l.Popularity), 0))
+			landmarks[name] += w.Of(p.PhotoID) * closeness * popularity
+			near[name] += w.Of(p.PhotoID)
+		}
+	}
+
+	r := Rank(landmarks)
+	for i := range r {
+		r[i].Share = 0
+		if total > 0 {
+			r[i].Share = near[r[i].Name] / total
+		}
+	}
+
+	return r
+}
+
+// TopLocation is GetTopLocation with weighted photos.
+func (w Weights) TopLocation(articleLocation []photo.LocationM) (string, string, error) {
+	countries, cities := w.LocationRanking(articleLocation)
+	if cities.First().Name == "" {
+		return countries.First().Name, "", errors.New("no photo city could be located")
+	}
+	return countries.First().Name, cities.First().Name, nil
+}
+
+// TopWeather is GetTopWeather with weighted photos.
+func (w Weights) TopWeather(weatherData []photo.WeatherM) string {
+	return w.WeatherRanking(weatherData).First().Name
+}
+
+// TopTimeInfo is GetTopTimeInfo with weighted photos.
+func (w Weights) TopTimeInfo(weatherData []photo.WeatherM) (string, string, string) {
+	weekdays, months, seasons := w.TimeInfoRanking(weatherData)
+	return weekdays.First().Name, months.First().Name, seasons.First().Name
+}
+
+// TopPlaceOfInterest is GetTopPlaceOfInterest with weighted photos.
+func (w Weights) TopPlaceOfInterest(poiData []photo.PoiM) string {
+	return w.PlaceOfInterestRanking(poiData).First().Name
+}
+
+// shares provides the shares of the summary information.
+func (w Weights) shares(s Summary, articleLocation []photo.LocationM, weatherData []photo.WeatherM,
+	poiData []photo.PoiM,
+) Shares {
+	countries, cities := w.LocationRanking(articleLocation)
+	weekdays, months, seasons := w.TimeInfoRanking(weatherData)
+
+	return Shares{
+		Country:         countries.ShareOf(s.Country),
+		City:            cities.ShareOf(s.City),
+		Weather:         w.WeatherRanking(weatherData).ShareOf(s.Weather),
+		Weekday:         weekdays.ShareOf(s.Weekday),
+		Month:           months.ShareOf(s.Month),
+		Season:          seasons.ShareOf(s.Season),
+		TimeOfDay:       w.TimeOfDayRanking(weatherData).ShareOf(s.TimeOfDay),
+		PlaceOfInterest: w.PlaceOfInterestRanking(poiData).ShareOf(s.PlaceOfInterest),
+		Holiday:         w.HolidayRanking(weatherData).ShareOf(s.Holiday),
+	}
+}
@@ -0,0 +1,230 @@
+// +build unit_tests
+
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+)
+
+// gasStation is ten photos taken in five minutes, followed by three photos taken
+// hours apart in Sorrento.
+var gasStation = []photo.Data{
+	{ID: 1, Date: "2019-10-27T08:00:00Z"},
+	{ID: 2, Date: "2019-10-27T08:00:30Z"},
+	{ID: 3, Date: "2019-10-27T08:01:00Z"},
+	{ID: 4, Date: "2019-10-27T08:01:30Z"},
+	{ID: 5, Date: "2019-10-27T08:02:00Z"},
+	{ID: 6, Date: "2019-10-27T08:02:30Z"},
+	{ID: 7, Date: "2019-10-27T08:03:00Z"},
+	{ID: 8, Date: "2019-10-27T08:03:30Z"},
+	{ID: 9, Date: "2019-10-27T08:04:00Z"},
+	{ID: 10, Date: "2019-10-27T08:05:00Z"},
+	{ID: 11, Date: "2019-10-27T10:00:00Z"},
+	{ID: 12, Date: "2019-10-27T12:00:00Z"},
+	{ID: 13, Date: "2019-10-27T14:00:00Z"},
+}
+
+func TestParseWeighting(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    service.Weighting
+		wantErr bool
+	}{
+		{s: "", want: service.CountWeighting},
+		{s: "count", want: service.CountWeighting},
+		{s: "dwell", want: service.DwellWeighting},
+		{s: "hybrid", want: service.HybridWeighting},
+		{s: "time", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := service.ParseWeighting(tt.s)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewWeights(t *testing.T) {
+	tests := []struct {
+		name      string
+		photoL    []photo.Data
+		weighting service.Weighting
+		maxDwell  time.Duration
+		want      map[int]float64
+	}{
+		{
+			name:      "count",
+			photoL:    gasStation,
+			weighting: service.CountWeighting,
+			want:      map[int]float64{1: 1, 11: 1, 12: 1},
+		},
+		{
+			name: "dwell",
+			photoL: []photo.Data{
+				{ID: 1, Date: "2019-10-27T08:00:00Z"},
+				{ID: 2, Date: "2019-10-27T08:30:00Z"},
+				{ID: 3, Date: "2019-10-27T09:00:00Z"},
+			},
+			weighting: service.DwellWeighting,
+			// dwell times 15, 30 and 15 minutes.
+			want: map[int]float64{1: 0.75, 2: 1.5, 3: 0.75},
+		},
+		{
+			name: "capped dwell",
+			photoL: []photo.Data{
+				{ID: 1, Date: "2019-10-27T08:00:00Z"},
+				{ID: 2, Date: "2019-10-27T09:00:00Z"},
+				{ID: 3, Date: "2019-10-28T09:00:00Z"},
+			},
+			weighting: service.DwellWeighting,
+			maxDwell:  time.Hour,
+			// dwell times 30, 60 and 30 minutes.
+			want: map[int]float64{1: 0.75, 2: 1.5, 3: 0.75},
+		},
+		{
+			name: "hybrid",
+			photoL: []photo.Data{
+				{ID: 1, Date: "2019-10-27T08:00:00Z"},
+				{ID: 2, Date: "2019-10-27T08:30:00Z"},
+				{ID: 3, Date: "2019-10-27T09:00:00Z"},
+			},
+			weighting: service.HybridWeighting,
+			want:      map[int]float64{1: 0.875, 2: 1.25, 3: 0.875},
+		},
+		{
+			name: "invalid date",
+			photoL: []photo.Data{
+				{ID: 1, Date: "2019-10-27T08:00:00Z"},
+				{ID: 2, Date: "2019-10-27T08.30:00Z"},
+				{ID: 3, Date: "2019-10-27T09:00:00Z"},
+			},
+			weighting: service.DwellWeighting,
+			want:      map[int]float64{1: 1, 2: 1, 3: 1},
+		},
+		{
+			name: "same time",
+			photoL: []photo.Data{
+				{ID: 1, Date: "2019-10-27T08:00:00Z"},
+				{ID: 2, Date: "2019-10-27T08:00:00Z"},
+			},
+			weighting: service.DwellWeighting,
+			want:      map[int]float64{1: 1, 2: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := service.NewWeights(tt.photoL, tt.weighting, tt.maxDwell)
+			for id, want := range tt.want {
+				require.InDelta(t, want, w.Of(id), 0.001, "photo %d", id)
+			}
+		})
+	}
+}
+
+func TestWeights_Top(t *testing.T) {
+	locations := []photo.LocationM{}
+	weathers := []photo.WeatherM{}
+	pois := []photo.PoiM{}
+	for _, pd := range gasStation {
+		l, wt, poi := photo.Location{Country: "Italy", City: "Vico Equense"}, "rainy", map[string]int{"Gas stations": 1}
+		if pd.ID > 10 {
+			l, wt, poi = photo.Location{Country: "Italy", City: "Sorrento"}, "sunny", map[string]int{"Cafes": 3}
+		}
+		locations = append(locations, photo.LocationM{PhotoID: pd.ID, Location: l})
+		weathers = append(weathers, photo.WeatherM{PhotoID: pd.ID, Weather: wt})
+		pois = append(pois, photo.PoiM{PhotoID: pd.ID, POI: poi})
+	}
+
+	tests := []struct {
+		weighting   service.Weighting
+		wantCity    string
+		wantWeather string
+		wantPoi     string
+	}{
+		{weighting: service.CountWeighting, wantCity: "Vico Equense", wantWeather: "rainy", wantPoi: "Gas stations"},
+		{weighting: service.DwellWeighting, wantCity: "Sorrento", wantWeather: "sunny", wantPoi: "Cafes"},
+		{weighting: service.HybridWeighting, wantCity: "Sorrento", wantWeather: "sunny", wantPoi: "Cafes"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.weighting), func(t *testing.T) {
+			w := service.NewWeights(gasStation, tt.weighting, service.DefaultMaxDwell)
+
+			_, city, err := w.TopLocation(locations)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCity, city)
+			require.Equal(t, tt.wantWeather, w.TopWeather(weathers))
+			require.Equal(t, tt.wantPoi, w.TopPlaceOfInterest(pois))
+		})
+	}
+}
+
+func TestWeights_DistinctivePlacesRanking(t *testing.T) {
+	pois := []photo.PoiM{
+		{PhotoID: 1, POI: map[string]int{"Restaurants": 8, "Cafes": 4, "Museums": 4}},
+		{PhotoID: 2, POI: map[string]int{"Restaurants": 4, "Cafes": 2, "Museums": 2, "Botanical Gardens": 3}},
+	}
+	w := service.NewWeights(nil, service.CountWeighting, service.DefaultMaxDwell)
+
+	// restaurants are the most common, but museums and botanical gardens are the most distinctive.
+	r := w.DistinctivePlacesRanking(pois, poi.Default(), "")
+	require.Equal(t, []string{"Museums", "Botanical Gardens", "Restaurants", "Cafes"}, r.Names())
+	require.InDelta(t, 6.0/27, r.First().Share, 0.0001)
+	require.Greater(t, r[2].Weight, 0.0)
+
+	// restaurants are more common in Italy.
+	r = w.DistinctivePlacesRanking(pois, poi.Default(), "ITA")
+	require.Equal(t, []string{"Botanical Gardens", "Museums", "Cafes", "Restaurants"}, r.Names())
+
+	// the places are ranked by their share without a baseline.
+	r = w.DistinctivePlacesRanking(pois, nil, "ITA")
+	require.Equal(t, []string{"Restaurants", "Cafes", "Museums", "Botanical Gardens"}, r.Names())
+	require.Equal(t, r.First().Share, r.First().Weight)
+}
+
+func TestWeights_LandmarkRanking(t *testing.T) {
+	pois := []photo.PoiM{
+		{PhotoID: 1, Landmarks: []photo.Landmark{
+			{Name: "Colosseum", Distance: 300, Popularity: 300000},
+			{Name: "Arch of Constantine", Distance: 50, Popularity: 20000},
+		}},
+		{PhotoID: 2, Landmarks: []photo.Landmark{
+			{Name: "Colosseum", Distance: 100, Popularity: 300000},
+			// the nearest of the same name counts.
+			{Name: "Colosseum", Distance: 400, Popularity: 300000},
+		}},
+		{PhotoID: 3, Landmarks: []photo.Landmark{
+			{Name: "Colosseum", Distance: 450, Popularity: 300000},
+			{Name: "Ludus Magnus", Distance: 20, Popularity: 900},
+		}},
+		{PhotoID: 4},
+	}
+
+	w := service.NewWeights(nil, service.CountWeighting, service.DefaultMaxDwell)
+	r := w.LandmarkRanking(pois)
+	require.Equal(t, []string{"Colosseum", "Arch of Constantine", "Ludus Magnus"}, r.Names())
+	require.Equal(t, 0.75, r.First().Share)
+	require.Equal(t, 0.25, r.ShareOf("Arch of Constantine"))
+
+	// a close landmark outweighs a popular landmark further away.
+	r = w.LandmarkRanking(pois[:1])
+	require.Equal(t, []string{"Arch of Constantine", "Colosseum"}, r.Names())
+
+	// photos near a landmark count by their weight.
+	w = service.Weights{1: 10}
+	r = w.LandmarkRanking(pois)
+	require.Equal(t, "Arch of Constantine", r.First().Name)
+	require.InDelta(t, 12.0/13, r.ShareOf("Colosseum"), 0.0001)
+
+	require.Empty(t, w.LandmarkRanking(nil))
+}
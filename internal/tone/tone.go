@@ -0,0 +1,210 @@
+// Package tone provides the editorial tones of the headings, eg family or nightlife. A tone picks
+// the heading vocabulary, prefers its places of interest and avoids the heading patterns that do not
+// suit it. The tone is chosen per run, per article by a sidecar metadata file, or inferred from the mix
+// of the places of interest near the article photos.
+package tone
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Auto infers the tone from the places of interest.
+const Auto = "auto"
+
+// MinAffinity is the least share of the places of interest preferred by the tone, for the tone
+// to be inferred.
+const MinAffinity = 0.4
+
+// Tone of the headings. The vocabulary replaces the default one, if provided.
+type Tone struct {
+	Name string
+	// Places are the places of interest preferred by the tone.
+	Places []string
+	// Avoids lists the heading data fields, eg HappyStarts, the templates of the headings
+	// in this tone do not use.
+	Avoids []string
+
+	// vocabulary
+	Starts      []string
+	HappyStarts []string
+	Company     []string
+	ForPlaces   []string
+	Adjectives  []string
+}
+
+// Builtin tones.
+var (
+	Family = Tone{
+		Name:        "family",
+		Places:      []string{"Zoos", "Playgrounds", "Parks", "Swimming Pools"},
+		Starts:      []string{"Family adventures", "Make family memories", "Fun for all the family"},
+		HappyStarts: []string{"Enjoy happy days out", "Have a giggle"},
+		Company:     []string{"with the kids", "with family", "with the little ones"},
+		ForPlaces:   []string{"full of", "packed with", "with plenty of"},
+		Adjectives:  []string{"Family fun", "Happy", "Playful", "Carefree"},
+	}
+	Luxury = Tone{
+		Name:       "luxury",
+		Places:     []string{"Restaurants", "Casinos", "Theatres", "Shopping Centres"},
+		Avoids:     []string{"HappyStarts"},
+		Starts:     []string{"Indulge yourself", "Live the high life", "Treat yourself", "Pure indulgence"},
+		Company:    []string{"in style", "in luxury", "in comfort"},
+		ForPlaces:  []string{"renowned for", "famous for", "celebrated for"},
+		Adjectives: []string{"Exquisite", "Elegant", "Lavish", "Refined"},
+	}
+	Adventure = Tone{
+		Name:        "adventure",
+		Places:      []string{"Parks", "Botanical Gardens", "Swimming Pools", "Museums"},
+		Starts:      []string{"Go wild", "Adventure awaits", "Off the beaten track"},
+		HappyStarts: []string{"Chase the thrills", "Live on the edge"},
+		Company:     []string{"with friends", "on your own", "with fellow explorers"},
+		ForPlaces:   []string{"full of", "bursting with", "teeming with"},
+		Adjectives:  []string{"Wild", "Daring", "Epic", "Adventurous"},
+	}
+	Budget = Tone{
+		Name:        "budget",
+		Places:      []string{"Cafes", "Pubs", "Parks", "Museums"},
+		Starts:      []string{"Travel on a shoestring", "Great value break", "Make the most of your money"},
+		HappyStarts: []string{"Have fun for less", "Enjoy happy days for less"},
+		Company:     []string{"with friends", "with family", "on your own"},
+		ForPlaces:   []string{"full of", "packed with", "brimming with"},
+		Adjectives:  []string{"Affordable", "Thrifty", "Cheap and cheerful", "Great value"},
+	}
+	Romantic = Tone{
+		Name:       "romantic",
+		Places:     []string{"Restaurants", "Theatres", "Cafes", "Botanical Gardens"},
+		Avoids:     []string{"HappyStarts"},
+		Starts:     []string{"Fall in love", "Getaway for two", "Escape together", "Romance blossoms"},
+		Company:    []string{"for two", "with your partner", "with your loved one"},
+		ForPlaces:  []string{"full of", "dotted with", "graced with"},
+		Adjectives: []string{"Romantic", "Dreamy", "Enchanting", "Intimate"},
+	}
+	Nightlife = Tone{
+		Name:        "nightlife",
+		Places:      []string{"Bars", "Pubs", "Casinos", "Cinemas"},
+		Starts:      []string{"Paint the town red", "Dance till dawn", "Party all night"},
+		HappyStarts: []string{"Live it up", "Have a wild night out"},
+		Company:     []string{"with friends", "with your crew", "with the gang"},
+		ForPlaces:   []string{"buzzing with", "full of", "packed with"},
+		Adjectives:  []string{"Electric", "Lively", "Buzzing", "Vibrant"},
+	}
+)
+
+// Builtin lists the builtin tones.
+func Builtin() []Tone {
+	return []Tone{Family, Luxury, Adventure, Budget, Romantic, Nightlife}
+}
+
+// Names lists the names of the builtin tones.
+func Names() []string {
+	names := []string{}
+	for _, t := range Builtin() {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// Lookup provides the builtin tone.
+func Lookup(name string) (Tone, error) {
+	for _, t := range Builtin() {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Tone{}, errors.Errorf("unknown tone %q: expected %s or one of %s", name, Auto, strings.Join(Names(), ", "))
+}
+
+// Validate checks the tone name is empty (no tone), Auto or the name of a builtin tone.
+func Validate(name string) error {
+	if name == "" || name == Auto {
+		return nil
+	}
+	_, err := Lookup(name)
+	return err
+}
+
+// Infer provides the tone preferring the largest share of the places of interest, given the shares
+// (0-1) of the places of interest by kind. No tone is inferred if the largest share is below MinAffinity.
+// Tones with the same share are chosen in the order of the builtin tones.
+func Infer(shares map[string]float64) (Tone, bool) {
+	best, bestAffinity := Tone{}, 0.0
+	for _, t := range Builtin() {
+		affinity := 0.0
+		for _, p := range t.Places {
+			affinity += shares[p]
+		}
+		if affinity > bestAffinity {
+			best, bestAffinity = t, affinity
+		}
+	}
+	if bestAffinity < MinAffinity {
+		return Tone{}, false
+	}
+	return best, true
+}
+
+// Prefers is true if the tone prefers the place of interest.
+func (t Tone) Prefers(place string) bool {
+	for _, p := range t.Places {
+		if p == place {
+			return true
+		}
+	}
+	return false
+}
+
+// Suits is true if the heading template does not use any of the fields the tone avoids.
+func (t Tone) Suits(template string) bool {
+	for _, f := range t.Avoids {
+		if strings.Contains(template, "."+f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Metadata of the article, read from its sidecar file.
+type Metadata struct {
+	// Tone of the article headings: the name of a builtin tone or auto.
+	Tone string `json:"tone"`
+}
+
+// SidecarExt is the extension of the article sidecar metadata files.
+const SidecarExt = ".meta.json"
+
+// SidecarPath provides the path of the article sidecar metadata file, eg data/article1.meta.json
+// of data/article1.csv.
+func SidecarPath(articlePath string) string {
+	return strings.TrimSuffix(articlePath, filepath.Ext(articlePath)) + SidecarExt
+}
+
+// IsSidecar is true for the paths of the sidecar metadata files.
+func IsSidecar(path string) bool {
+	return strings.HasSuffix(path, SidecarExt)
+}
+
+// ReadMetadata reads the sidecar metadata of the article, in JSON format, eg {"tone": "family"}.
+// Articles without a sidecar file have empty metadata.
+func ReadMetadata(articlePath string) (Metadata, error) {
+	b, err := os.ReadFile(SidecarPath(articlePath))
+	if os.IsNotExist(err) {
+		return Metadata{}, nil
+	}
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	m := Metadata{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return Metadata{}, errors.Wrapf(err, "invalid article metadata %s", SidecarPath(articlePath))
+	}
+	if err := Validate(m.Tone); err != nil {
+		return Metadata{}, errors.Wrapf(err, "invalid article metadata %s", SidecarPath(articlePath))
+	}
+	return m, nil
+}
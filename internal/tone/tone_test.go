@@ -0,0 +1,123 @@
+// +build unit_tests
+
+package tone_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range tone.Names() {
+		tn, err := tone.Lookup(name)
+		require.NoError(t, err)
+		require.Equal(t, name, tn.Name)
+		require.NotEmpty(t, tn.Places)
+		require.NotEmpty(t, tn.Adjectives)
+	}
+
+	_, err := tone.Lookup("gloomy")
+	require.EqualError(t, err,
+		`unknown tone "gloomy": expected auto or one of family, luxury, adventure, budget, romantic, nightlife`)
+
+	require.NoError(t, tone.Validate(""))
+	require.NoError(t, tone.Validate(tone.Auto))
+	require.NoError(t, tone.Validate("family"))
+	require.Error(t, tone.Validate("Family"))
+}
+
+func TestInfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		shares   map[string]float64
+		want     string
+		inferred bool
+	}{
+		{
+			name:     "family",
+			shares:   map[string]float64{"Zoos": 0.2, "Parks": 0.3, "Restaurants": 0.5},
+			want:     "family",
+			inferred: true,
+		},
+		{
+			name:     "nightlife",
+			shares:   map[string]float64{"Bars": 0.4, "Casinos": 0.2, "Parks": 0.3, "Museums": 0.1},
+			want:     "nightlife",
+			inferred: true,
+		},
+		{
+			name:     "romantic over luxury",
+			shares:   map[string]float64{"Restaurants": 0.3, "Cafes": 0.3, "Casinos": 0.1, "Bars": 0.3},
+			want:     "romantic",
+			inferred: true,
+		},
+		{
+			name:     "same share",
+			shares:   map[string]float64{"Restaurants": 0.5, "Bars": 0.5},
+			want:     "luxury",
+			inferred: true,
+		},
+		{
+			name:   "mixed",
+			shares: map[string]float64{"Restaurants": 0.3, "Bars": 0.3, "Zoos": 0.3, "Museums": 0.1},
+		},
+		{
+			name: "no places of interest",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, inferred := tone.Infer(tt.shares)
+			require.Equal(t, tt.inferred, inferred)
+			require.Equal(t, tt.want, got.Name)
+		})
+	}
+}
+
+func TestTone_Suits(t *testing.T) {
+	require.True(t, tone.Romantic.Suits("{{pick .Starts}} {{pick .Company}} in {{.City}}"))
+	require.False(t, tone.Romantic.Suits("{{pick .HappyStarts}} {{pick .Company}} in {{.City}}"))
+	require.True(t, tone.Family.Suits("{{pick .HappyStarts}} {{pick .Company}} in {{.City}}"))
+	require.True(t, tone.Tone{}.Suits("{{pick .HappyStarts}}"))
+
+	require.True(t, tone.Family.Prefers("Zoos"))
+	require.False(t, tone.Family.Prefers("Casinos"))
+}
+
+func TestReadMetadata(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		fp := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(fp, []byte(content), 0o600))
+		return fp
+	}
+
+	article := write("article1.csv", "")
+	require.Equal(t, filepath.Join(dir, "article1.meta.json"), tone.SidecarPath(article))
+	require.True(t, tone.IsSidecar(tone.SidecarPath(article)))
+	require.False(t, tone.IsSidecar(article))
+
+	// no sidecar file.
+	m, err := tone.ReadMetadata(article)
+	require.NoError(t, err)
+	require.Equal(t, tone.Metadata{}, m)
+
+	write("article1.meta.json", `{"tone": "family"}`)
+	m, err = tone.ReadMetadata(article)
+	require.NoError(t, err)
+	require.Equal(t, tone.Metadata{Tone: "family"}, m)
+
+	write("article1.meta.json", `{"tone": "gloomy"}`)
+	_, err = tone.ReadMetadata(article)
+	require.Error(t, err)
+
+	write("article1.meta.json", `{"tone":`)
+	_, err = tone.ReadMetadata(article)
+	require.Error(t, err)
+}
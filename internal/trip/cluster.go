@@ -0,0 +1,212 @@
+package trip
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+// ClusterConfig holds the density-based clustering (DBSCAN) parameters.
+type ClusterConfig struct {
+	// Eps (km) is the neighbourhood radius of a photo.
+	Eps float64
+	// MinPoints is the least number of photos in a neighbourhood (including the photo)
+	// to form a cluster.
+	MinPoints int
+}
+
+// DefaultClusterConfig is used when no clustering parameters are configured.
+var DefaultClusterConfig = ClusterConfig{
+	Eps:       1,
+	MinPoints: 3,
+}
+
+// Cluster is a dense area of photos.
+type Cluster struct {
+	PhotoIDs []int
+	// Lat, Lon is the cluster centroid.
+	Lat, Lon float64
+	// Spread (km) is the mean distance of the cluster photos from the centroid.
+	Spread float64
+	// Positions is the number of distinct photo positions (rounded to about 10m).
+	// Bursts of photos taken in one spot count once.
+	Positions int
+	// Medoid is the photo closest to the centroid.
+	Medoid int
+}
+
+// Positions converts the photos with a valid position into points.
+// Unlike Points, the photo date is not required.
+func Positions(photoL []photo.Data) []Point {
+	points := []Point{}
+	for _, pd := range photoL {
+		lat, errLat := strconv.ParseFloat(pd.LatLon.Latitude, 64)
+		lon, errLon := strconv.ParseFloat(pd.LatLon.Longitude, 64)
+		if errLat != nil || errLon != nil || (lat == 0 && lon == 0) {
+			continue
+		}
+		t, _ := photo.ParseDate(pd.Date)
+		points = append(points, Point{PhotoID: pd.ID, Time: t, Lat: lat, Lon: lon})
+	}
+	return points
+}
+
+// DBSCAN clusters the points by density. Points that do not belong to any cluster
+// are returned as noise. Clusters are ordered by dominance.
+func DBSCAN(points []Point, cfg ClusterConfig) ([]Cluster, []int) {
+	if cfg.Eps <= 0 {
+		cfg.Eps = DefaultClusterConfig.Eps
+	}
+	if cfg.MinPoints <= 0 {
+		cfg.MinPoints = DefaultClusterConfig.MinPoints
+	}
+
+	const (
+		unvisited = 0
+		noise     = -1
+	)
+	labels := make([]int, len(points))
+
+	neighbours := func(i int) []int {
+		ns := []int{}
+		for j := range points {
+			if Haversine(points[i].Lat, points[i].Lon, points[j].Lat, points[j].Lon) <= cfg.Eps {
+				ns = append(ns, j)
+			}
+		}
+		return ns
+	}
+
+	c := 0
+	for i := range points {
+		if labels[i] != unvisited {
+			continue
+		}
+		ns := neighbours(i)
+		if len(ns) < cfg.MinPoints {
+			labels[i] = noise
+			continue
+		}
+
+		c++
+		labels[i] = c
+		for k := 0; k < len(ns); k++ {
+			j := ns[k]
+			if labels[j] == noise {
+				// border point
+				labels[j] = c
+			}
+			if labels[j] != unvisited {
+				continue
+			}
+			labels[j] = c
+			if jns := neighbours(j); len(jns) >= cfg.MinPoints {
+				ns = append(ns, jns...)
+			}
+		}
+	}
+
+	members := make([][]Point, c)
+	noiseIDs := []int{}
+	for i, l := range labels {
+		if l == noise {
+			noiseIDs = append(noiseIDs, points[i].PhotoID)
+			continue
+		}
+		members[l-1] = append(members[l-1], points[i])
+	}
+
+	clusters := make([]Cluster, 0, c)
+	for _, ps := range members {
+		clusters = append(clusters, newCluster(ps))
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return dominates(clusters[i], clusters[j])
+	})
+
+	return clusters, noiseIDs
+}
+
+// ClusterStops clusters the points of each itinerary stop separately, so that stops connected
+// by the photos taken in between are not merged into one cluster. The points of no stop (eg photos
+// without a date) are clustered together. Clusters are ordered by dominance.
+func ClusterStops(points []Point, it Itinerary, cfg ClusterConfig) ([]Cluster, []int) {
+	stopOf := map[int]int{}
+	for i, s := range it {
+		for _, id := range s.PhotoIDs {
+			stopOf[id] = i + 1
+		}
+	}
+	groups := make([][]Point, len(it)+1)
+	for _, p := range points {
+		groups[stopOf[p.PhotoID]] = append(groups[stopOf[p.PhotoID]], p)
+	}
+
+	clusters := []Cluster{}
+	noiseIDs := []int{}
+	for _, g := range groups {
+		cs, noise := DBSCAN(g, cfg)
+		clusters = append(clusters, cs...)
+		noiseIDs = append(noiseIDs, noise...)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return dominates(clusters[i], clusters[j])
+	})
+
+	return clusters, noiseIDs
+}
+
+// Dominant is the cluster covering the most distinct positions. Ties are broken
+// by the number of photos and then by the smaller spread.
+func Dominant(clusters []Cluster) (Cluster, bool) {
+	if len(clusters) == 0 {
+		return Cluster{}, false
+	}
+	d := clusters[0]
+	for _, c := range clusters[1:] {
+		if dominates(c, d) {
+			d = c
+		}
+	}
+	return d, true
+}
+
+func dominates(c1, c2 Cluster) bool {
+	if c1.Positions != c2.Positions {
+		return c1.Positions > c2.Positions
+	}
+	if len(c1.PhotoIDs) != len(c2.PhotoIDs) {
+		return len(c1.PhotoIDs) > len(c2.PhotoIDs)
+	}
+	return c1.Spread < c2.Spread
+}
+
+func newCluster(ps []Point) Cluster {
+	c := Cluster{}
+	positions := map[string]bool{}
+	for _, p := range ps {
+		c.PhotoIDs = append(c.PhotoIDs, p.PhotoID)
+		c.Lat += p.Lat
+		c.Lon += p.Lon
+		positions[fmt.Sprintf("%.4f,%.4f", p.Lat, p.Lon)] = true
+	}
+	n := float64(len(ps))
+	c.Lat /= n
+	c.Lon /= n
+	c.Positions = len(positions)
+
+	min := -1.0
+	for _, p := range ps {
+		d := Haversine(c.Lat, c.Lon, p.Lat, p.Lon)
+		c.Spread += d
+		if min < 0 || d < min {
+			min = d
+			c.Medoid = p.PhotoID
+		}
+	}
+	c.Spread /= n
+
+	return c
+}
@@ -0,0 +1,219 @@
+// +build unit_tests
+
+package trip_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
+)
+
+// around places n points on a circle with the radius r (km) around lat, lon.
+func around(id *int, lat, lon, r float64, n int) []trip.Point {
+	points := []trip.Point{}
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		*id++
+		points = append(points, trip.Point{
+			PhotoID: *id,
+			Lat:     lat + r/111.2*math.Sin(a),
+			Lon:     lon + r/(111.2*math.Cos(lat*math.Pi/180))*math.Cos(a),
+		})
+	}
+	return points
+}
+
+// burst places n points at lat, lon.
+func burst(id *int, lat, lon float64, n int) []trip.Point {
+	points := []trip.Point{}
+	for i := 0; i < n; i++ {
+		*id++
+		points = append(points, trip.Point{PhotoID: *id, Lat: lat, Lon: lon})
+	}
+	return points
+}
+
+func TestDBSCAN(t *testing.T) {
+	tests := []struct {
+		name         string
+		points       func(id *int) []trip.Point
+		cfg          trip.ClusterConfig
+		wantClusters []int // number of photos of the clusters ordered by dominance
+		wantNoise    int
+	}{
+		{
+			name: "two clusters and outliers",
+			points: func(id *int) []trip.Point {
+				ps := around(id, 40.626, 14.376, 0.3, 8)               // Sorrento
+				ps = append(ps, around(id, 40.634, 14.603, 0.3, 5)...) // Amalfi
+				ps = append(ps, trip.Point{PhotoID: 100, Lat: 40.852, Lon: 14.268})
+				ps = append(ps, trip.Point{PhotoID: 101, Lat: 41.903, Lon: 12.496})
+				return ps
+			},
+			cfg:          trip.DefaultClusterConfig,
+			wantClusters: []int{8, 5},
+			wantNoise:    2,
+		},
+		{
+			name: "all noise",
+			points: func(id *int) []trip.Point {
+				return []trip.Point{
+					{PhotoID: 1, Lat: 40.626, Lon: 14.376},
+					{PhotoID: 2, Lat: 40.852, Lon: 14.268},
+					{PhotoID: 3, Lat: 41.903, Lon: 12.496},
+				}
+			},
+			cfg:          trip.DefaultClusterConfig,
+			wantClusters: []int{},
+			wantNoise:    3,
+		},
+		{
+			name: "burst does not dominate",
+			points: func(id *int) []trip.Point {
+				ps := burst(id, 40.852, 14.268, 20)                    // Naples
+				ps = append(ps, around(id, 40.626, 14.376, 0.3, 6)...) // Sorrento
+				return ps
+			},
+			cfg:          trip.DefaultClusterConfig,
+			wantClusters: []int{6, 20},
+			wantNoise:    0,
+		},
+		{
+			name: "chained neighbourhoods",
+			points: func(id *int) []trip.Point {
+				ps := []trip.Point{}
+				for i := 0; i < 10; i++ {
+					// 0.5km apart
+					ps = append(ps, trip.Point{PhotoID: i + 1, Lat: 40.626 + 0.0045*float64(i), Lon: 14.376})
+				}
+				return ps
+			},
+			cfg:          trip.DefaultClusterConfig,
+			wantClusters: []int{10},
+			wantNoise:    0,
+		},
+		{
+			name: "too few points",
+			points: func(id *int) []trip.Point {
+				return around(id, 40.626, 14.376, 0.3, 2)
+			},
+			cfg:          trip.DefaultClusterConfig,
+			wantClusters: []int{},
+			wantNoise:    2,
+		},
+		{
+			name: "min points",
+			points: func(id *int) []trip.Point {
+				return around(id, 40.626, 14.376, 0.3, 2)
+			},
+			cfg:          trip.ClusterConfig{Eps: 1, MinPoints: 2},
+			wantClusters: []int{2},
+			wantNoise:    0,
+		},
+		{
+			name: "small neighbourhood",
+			points: func(id *int) []trip.Point {
+				return around(id, 40.626, 14.376, 0.3, 8)
+			},
+			cfg:          trip.ClusterConfig{Eps: 0.1, MinPoints: 3},
+			wantClusters: []int{},
+			wantNoise:    8,
+		},
+		{
+			name: "not configured",
+			points: func(id *int) []trip.Point {
+				return around(id, 40.626, 14.376, 0.3, 8)
+			},
+			cfg:          trip.ClusterConfig{},
+			wantClusters: []int{8},
+			wantNoise:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := 0
+			clusters, noise := trip.DBSCAN(tt.points(&id), tt.cfg)
+
+			got := []int{}
+			for _, c := range clusters {
+				got = append(got, len(c.PhotoIDs))
+			}
+			require.Equal(t, tt.wantClusters, got)
+			require.Len(t, noise, tt.wantNoise)
+		})
+	}
+}
+
+func TestDBSCAN_Centroid(t *testing.T) {
+	id := 0
+	points := around(&id, 40.626, 14.376, 0.3, 8)
+	points = append(points, trip.Point{PhotoID: 100, Lat: 41.903, Lon: 12.496})
+
+	clusters, noise := trip.DBSCAN(points, trip.DefaultClusterConfig)
+	require.Len(t, clusters, 1)
+	require.Equal(t, []int{100}, noise)
+
+	c := clusters[0]
+	require.InDelta(t, 40.626, c.Lat, 0.0001)
+	require.InDelta(t, 14.376, c.Lon, 0.0001)
+	require.InDelta(t, 0.3, c.Spread, 0.01)
+	require.Equal(t, 8, c.Positions)
+	require.Contains(t, c.PhotoIDs, c.Medoid)
+}
+
+func TestClusterStops(t *testing.T) {
+	id := 0
+	sorrento := around(&id, 40.626, 14.376, 0.3, 6)
+	positano := around(&id, 40.628, 14.485, 0.3, 6)
+	points := append(append([]trip.Point{}, sorrento...), positano...)
+	points = append(points, trip.Point{PhotoID: 100, Lat: 40.640, Lon: 14.430})
+	it := trip.Itinerary{{PhotoIDs: []int{1, 2, 3, 4, 5, 6}}, {PhotoIDs: []int{7, 8, 9, 10, 11, 12}}}
+
+	// the stops are density-connected with the wide neighbourhood.
+	cfg := trip.ClusterConfig{Eps: 10, MinPoints: 3}
+	clusters, noise := trip.DBSCAN(points, cfg)
+	require.Len(t, clusters, 1)
+	require.Empty(t, noise)
+
+	clusters, noise = trip.ClusterStops(points, it, cfg)
+	require.Len(t, clusters, 2)
+	require.ElementsMatch(t, [][]int{{1, 2, 3, 4, 5, 6}, {7, 8, 9, 10, 11, 12}},
+		[][]int{clusters[0].PhotoIDs, clusters[1].PhotoIDs})
+	// the point of no stop is not clustered alone.
+	require.Equal(t, []int{100}, noise)
+}
+
+func TestDominant(t *testing.T) {
+	_, ok := trip.Dominant(nil)
+	require.False(t, ok)
+
+	id := 0
+	points := burst(&id, 40.852, 14.268, 20)
+	points = append(points, around(&id, 40.626, 14.376, 0.3, 6)...)
+	points = append(points, around(&id, 40.634, 14.603, 0.6, 6)...)
+	clusters, _ := trip.DBSCAN(points, trip.DefaultClusterConfig)
+	require.Len(t, clusters, 3)
+
+	// the same number of positions and photos, the smaller spread wins.
+	d, ok := trip.Dominant(clusters)
+	require.True(t, ok)
+	require.InDelta(t, 40.626, d.Lat, 0.0001)
+	require.Equal(t, clusters[0], d)
+}
+
+func TestPositions(t *testing.T) {
+	photoL := []photo.Data{
+		{ID: 1, Date: "2019-10-27T13:27:58Z", LatLon: photo.LatLon{Latitude: "40.647863", Longitude: "14.366958"}},
+		{ID: 2, Date: "2020-11-24T06.28:00Z", LatLon: photo.LatLon{Latitude: "36.242175", Longitude: "-116.160645"}},
+		{ID: 3, Date: "2019-10-30 19:25:32", LatLon: photo.LatLon{Latitude: "0", Longitude: "0"}},
+		{ID: 4, Date: "2019-10-30 19:25:32", LatLon: photo.LatLon{Latitude: "north", Longitude: "14.3"}},
+	}
+
+	points := trip.Positions(photoL)
+	require.Len(t, points, 2)
+	require.Equal(t, 1, points[0].PhotoID)
+	require.Equal(t, 2, points[1].PhotoID)
+}
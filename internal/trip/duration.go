@@ -0,0 +1,122 @@
+package trip
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/tz"
+)
+
+// Duration is the class of the trip length and of the days of the week it covers.
+type Duration string
+
+// Duration classes.
+const (
+	// DayTrip is a trip within one day.
+	DayTrip Duration = "day trip"
+	// WeekendBreak is a trip within Friday to Sunday, covering Saturday or Sunday.
+	WeekendBreak Duration = "weekend break"
+	// LongWeekend is a trip of up to MaxShortTrip days covering a weekend and more
+	// than Friday to Sunday, eg Saturday to Monday.
+	LongWeekend Duration = "long weekend"
+	// MidweekEscape is a trip of up to MaxShortTrip days between Monday and Friday.
+	MidweekEscape Duration = "midweek escape"
+	// WeekLong is a trip of up to MaxHoliday days.
+	WeekLong Duration = "week-long holiday"
+	// GrandTour is a trip longer than MaxHoliday days.
+	GrandTour Duration = "grand tour"
+)
+
+// Duration class limits (days).
+const (
+	MaxShortTrip = 5
+	MaxHoliday   = 10
+)
+
+// Span is the calendar days the trip photos were taken on.
+type Span struct {
+	First, Last time.Time
+	// Days is the number of calendar days from the First to the Last day.
+	Days int
+	// PhotoDays is the number of the days with photos.
+	PhotoDays int
+	Duration  Duration
+}
+
+// Days provides the sorted calendar days of the photos, in the local time of the photo position.
+// Photos with an invalid date are left out. Dates of photos without a valid position are
+// taken as they are.
+func Days(photoL []photo.Data) []time.Time {
+	seen := map[time.Time]bool{}
+	days := []time.Time{}
+	for _, pd := range photoL {
+		t, err := photo.ParseDate(pd.Date)
+		if err != nil {
+			continue
+		}
+		lat, errLat := strconv.ParseFloat(pd.LatLon.Latitude, 64)
+		lon, errLon := strconv.ParseFloat(pd.LatLon.Longitude, 64)
+		if errLat == nil && errLon == nil && (lat != 0 || lon != 0) {
+			t = tz.Default().Local(t, lat, lon)
+		}
+
+		y, m, d := t.Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Before(days[j])
+	})
+
+	return days
+}
+
+// Classify classifies the trip by the sorted calendar days of its photos. The Duration
+// of a trip without any days is empty.
+func Classify(days []time.Time) Span {
+	if len(days) == 0 {
+		return Span{}
+	}
+
+	first, last := days[0], days[len(days)-1]
+	s := Span{
+		First:     first,
+		Last:      last,
+		Days:      int(last.Sub(first).Hours()/24) + 1,
+		PhotoDays: len(days),
+	}
+
+	// the days of the week covered by the trip, including those without photos.
+	weekend, beyondWeekend := false, false
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		switch d.Weekday() {
+		case time.Saturday, time.Sunday:
+			weekend = true
+		case time.Friday:
+		default:
+			beyondWeekend = true
+		}
+	}
+
+	switch {
+	case s.Days == 1:
+		s.Duration = DayTrip
+	case s.Days <= MaxShortTrip && weekend && !beyondWeekend:
+		s.Duration = WeekendBreak
+	case s.Days <= MaxShortTrip && weekend:
+		s.Duration = LongWeekend
+	case s.Days <= MaxShortTrip:
+		s.Duration = MidweekEscape
+	case s.Days <= MaxHoliday:
+		s.Duration = WeekLong
+	default:
+		s.Duration = GrandTour
+	}
+
+	return s
+}
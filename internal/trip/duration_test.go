@@ -0,0 +1,120 @@
+// +build unit_tests
+
+package trip_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
+)
+
+func TestClassify(t *testing.T) {
+	sorrento := photo.LatLon{Latitude: "40.626", Longitude: "14.376"}
+	newYork := photo.LatLon{Latitude: "40.713", Longitude: "-74.006"}
+	photos := func(ll photo.LatLon, dates ...string) []photo.Data {
+		photoL := []photo.Data{}
+		for i, d := range dates {
+			photoL = append(photoL, photo.Data{ID: i + 1, Date: d, LatLon: ll})
+		}
+		return photoL
+	}
+
+	tests := []struct {
+		name     string
+		photoL   []photo.Data
+		wantDays int
+		want     trip.Duration
+	}{
+		{
+			name:     "day trip",
+			photoL:   photos(sorrento, "2019-12-28T08:00:00Z", "2019-12-28T15:00:00Z", "2019-12-28T20:00:00Z"),
+			wantDays: 1,
+			want:     trip.DayTrip,
+		},
+		{
+			name:     "weekend break, Friday to Sunday",
+			photoL:   photos(sorrento, "2019-12-27T10:00:00Z", "2019-12-29T10:00:00Z"),
+			wantDays: 3,
+			want:     trip.WeekendBreak,
+		},
+		{
+			name:     "weekend break, Saturday to Sunday",
+			photoL:   photos(sorrento, "2019-12-28T10:00:00Z", "2019-12-29T10:00:00Z"),
+			wantDays: 2,
+			want:     trip.WeekendBreak,
+		},
+		{
+			name: "long weekend after midnight in the local time",
+			// 23:30 UTC on Sunday is Monday in Sorrento
+			photoL:   photos(sorrento, "2019-12-27T10:00:00Z", "2019-12-29T23:30:00Z"),
+			wantDays: 4,
+			want:     trip.LongWeekend,
+		},
+		{
+			name:     "long weekend across months",
+			photoL:   photos(sorrento, "2020-01-31T10:00:00Z", "2020-02-01T10:00:00Z", "2020-02-03T10:00:00Z"),
+			wantDays: 4,
+			want:     trip.LongWeekend,
+		},
+		{
+			name:     "midweek escape across years",
+			photoL:   photos(sorrento, "2019-12-30T10:00:00Z", "2020-01-02T10:00:00Z"),
+			wantDays: 4,
+			want:     trip.MidweekEscape,
+		},
+		{
+			name: "day trip before midnight in the local time",
+			// 03:00 UTC on Wednesday is Tuesday evening in New York
+			photoL:   photos(newYork, "2020-01-07T15:00:00Z", "2020-01-08T03:00:00Z"),
+			wantDays: 1,
+			want:     trip.DayTrip,
+		},
+		{
+			name:     "week-long holiday across the leap day",
+			photoL:   photos(sorrento, "2020-02-27T10:00:00Z", "2020-02-29T10:00:00Z", "2020-03-04T10:00:00Z"),
+			wantDays: 7,
+			want:     trip.WeekLong,
+		},
+		{
+			name:     "grand tour across years",
+			photoL:   photos(sorrento, "2019-12-20T10:00:00Z", "2019-12-25T10:00:00Z", "2020-01-05T10:00:00Z"),
+			wantDays: 17,
+			want:     trip.GrandTour,
+		},
+		{
+			name:     "photos without position",
+			photoL:   photos(photo.LatLon{Latitude: "0", Longitude: "0"}, "2020-01-06 10:00:00", "2020-01-10 10:00:00"),
+			wantDays: 5,
+			want:     trip.MidweekEscape,
+		},
+		{
+			name:   "no valid dates",
+			photoL: photos(sorrento, "yesterday"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := trip.Classify(trip.Days(tt.photoL))
+			require.Equal(t, tt.want, span.Duration)
+			require.Equal(t, tt.wantDays, span.Days)
+		})
+	}
+}
+
+func TestDays(t *testing.T) {
+	photoL := []photo.Data{
+		{ID: 1, Date: "2020-01-01T10:00:00Z", LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+		{ID: 2, Date: "2019-12-31T23:30:00Z", LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+		{ID: 3, Date: "2019-12-30T10:00:00Z", LatLon: photo.LatLon{Latitude: "40.626", Longitude: "14.376"}},
+		{ID: 4, Date: "yesterday"},
+	}
+
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	// 23:30 UTC on New Year's Eve is already the New Year in Sorrento
+	require.Equal(t, []time.Time{day(2019, time.December, 30), day(2020, time.January, 1)}, trip.Days(photoL))
+}
@@ -0,0 +1,216 @@
+// Package trip segments article photos into stops based on the time gaps between
+// the photos and the distance between the photo locations.
+package trip
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+// earthRadius in km.
+const earthRadius = 6371.0
+
+// Config holds the segmentation parameters.
+type Config struct {
+	// MaxGap is the longest time between two photos of the same stop.
+	MaxGap time.Duration
+	// MaxDistance (km) is the longest distance of a photo from the stop centre.
+	MaxDistance float64
+}
+
+// DefaultConfig is used when no segmentation parameters are configured.
+var DefaultConfig = Config{
+	MaxGap:      3 * time.Hour,
+	MaxDistance: 5,
+}
+
+// Point is a photo with a known date and position.
+type Point struct {
+	PhotoID  int
+	Time     time.Time
+	Lat, Lon float64
+}
+
+// Stop is a place where photos were taken close to each other in space and time.
+type Stop struct {
+	Start, End time.Time
+	// Lat, Lon is the stop centre.
+	Lat, Lon float64
+	PhotoIDs []int
+
+	// Location is the most frequent location of the stop photos.
+	Location photo.Location
+}
+
+// Itinerary holds the trip stops in chronological order.
+type Itinerary []Stop
+
+// Points converts the photos into points. Photos with invalid date or position
+// (including 0,0 of photos taken without a GPS fix) are left out.
+func Points(photoL []photo.Data) []Point {
+	points := []Point{}
+	for _, pd := range photoL {
+		t, err := photo.ParseDate(pd.Date)
+		if err != nil {
+			continue
+		}
+		lat, errLat := strconv.ParseFloat(pd.LatLon.Latitude, 64)
+		lon, errLon := strconv.ParseFloat(pd.LatLon.Longitude, 64)
+		if errLat != nil || errLon != nil || (lat == 0 && lon == 0) {
+			continue
+		}
+		points = append(points, Point{PhotoID: pd.ID, Time: t, Lat: lat, Lon: lon})
+	}
+	return points
+}
+
+// Segment splits the points into stops. A point joins the nearest stop that is
+// not further than MaxDistance and was last visited within MaxGap. Otherwise
+// it starts a new stop.
+func Segment(points []Point, cfg Config) Itinerary {
+	if cfg.MaxGap <= 0 {
+		cfg.MaxGap = DefaultConfig.MaxGap
+	}
+	if cfg.MaxDistance <= 0 {
+		cfg.MaxDistance = DefaultConfig.MaxDistance
+	}
+
+	sorted := make([]Point, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	it := Itinerary{}
+	for _, p := range sorted {
+		nearest, min := -1, math.MaxFloat64
+		for i := range it {
+			if p.Time.Sub(it[i].End) > cfg.MaxGap {
+				continue
+			}
+			d := Haversine(p.Lat, p.Lon, it[i].Lat, it[i].Lon)
+			if d <= cfg.MaxDistance && d < min {
+				nearest, min = i, d
+			}
+		}
+
+		if nearest < 0 {
+			it = append(it, Stop{Start: p.Time, End: p.Time, Lat: p.Lat, Lon: p.Lon, PhotoIDs: []int{p.PhotoID}})
+			continue
+		}
+
+		s := &it[nearest]
+		n := float64(len(s.PhotoIDs))
+		s.Lat = (s.Lat*n + p.Lat) / (n + 1)
+		s.Lon = (s.Lon*n + p.Lon) / (n + 1)
+		s.End = p.Time
+		s.PhotoIDs = append(s.PhotoIDs, p.PhotoID)
+	}
+
+	return it
+}
+
+// Locate sets the most frequent photo location of each stop. Stops without
+// any located photos are left out and consecutive stops in the same city are merged.
+func (it Itinerary) Locate(locations []photo.LocationM) Itinerary {
+	byPhoto := map[int]photo.Location{}
+	for _, l := range locations {
+		byPhoto[l.PhotoID] = l.Location
+	}
+
+	located := Itinerary{}
+	for _, s := range it {
+		counts := map[photo.Location]int{}
+		for _, id := range s.PhotoIDs {
+			if l, ok := byPhoto[id]; ok && l.City != "" {
+				counts[l]++
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		s.Location = mostFrequent(counts)
+
+		if last := len(located) - 1; last >= 0 && located[last].Location.City == s.Location.City {
+			located[last] = merge(located[last], s)
+			continue
+		}
+		located = append(located, s)
+	}
+
+	return located
+}
+
+// Cities lists the stop cities in the order they were first visited.
+func (it Itinerary) Cities() []string {
+	seen := map[string]bool{}
+	cities := []string{}
+	for _, s := range it {
+		if s.Location.City == "" || seen[s.Location.City] {
+			continue
+		}
+		seen[s.Location.City] = true
+		cities = append(cities, s.Location.City)
+	}
+	return cities
+}
+
+// Region is the state shared by all the stops, or the shared country if the stops
+// are in different states. It is empty for an international trip.
+func (it Itinerary) Region() string {
+	if len(it) == 0 {
+		return ""
+	}
+
+	state, country := it[0].Location.State, it[0].Location.Country
+	for _, s := range it[1:] {
+		if s.Location.State != state {
+			state = ""
+		}
+		if s.Location.Country != country {
+			country = ""
+		}
+	}
+	if state != "" {
+		return state
+	}
+	return country
+}
+
+// Haversine provides the great-circle distance in km.
+func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func merge(s1, s2 Stop) Stop {
+	n1, n2 := float64(len(s1.PhotoIDs)), float64(len(s2.PhotoIDs))
+	s1.Lat = (s1.Lat*n1 + s2.Lat*n2) / (n1 + n2)
+	s1.Lon = (s1.Lon*n1 + s2.Lon*n2) / (n1 + n2)
+	if s2.End.After(s1.End) {
+		s1.End = s2.End
+	}
+	s1.PhotoIDs = append(s1.PhotoIDs, s2.PhotoIDs...)
+	return s1
+}
+
+// mostFrequent breaks ties alphabetically to be deterministic.
+func mostFrequent(counts map[photo.Location]int) photo.Location {
+	var top photo.Location
+	max := 0
+	for l, c := range counts {
+		if c > max || (c == max && l.City < top.City) {
+			top, max = l, c
+		}
+	}
+	return top
+}
@@ -0,0 +1,227 @@
+// +build unit_tests
+
+package trip_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want                   float64
+	}{
+		{
+			name: "same place",
+			lat1: 40.626, lon1: 14.376, lat2: 40.626, lon2: 14.376,
+			want: 0,
+		},
+		{
+			name: "London to Paris",
+			lat1: 51.5074, lon1: -0.1278, lat2: 48.8566, lon2: 2.3522,
+			want: 343.6,
+		},
+		{
+			name: "Sorrento to Naples",
+			lat1: 40.626, lon1: 14.376, lat2: 40.852, lon2: 14.268,
+			want: 26.724,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.want, trip.Haversine(tt.lat1, tt.lon1, tt.lat2, tt.lon2), 0.5)
+		})
+	}
+}
+
+func TestSegment(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		cfg       trip.Config
+		wantStops int
+		wantFirst time.Time
+		wantLast  time.Time
+	}{
+		{
+			name:      "article1 - default",
+			file:      "../../data/article1.csv",
+			cfg:       trip.DefaultConfig,
+			wantStops: 8,
+			wantFirst: time.Date(2021, 3, 27, 14, 32, 2, 0, time.UTC),
+			wantLast:  time.Date(2021, 3, 29, 14, 1, 3, 0, time.UTC),
+		},
+		{
+			name:      "article1 - one stop a day",
+			file:      "../../data/article1.csv",
+			cfg:       trip.Config{MaxGap: 3 * time.Hour, MaxDistance: 200},
+			wantStops: 3,
+			wantFirst: time.Date(2021, 3, 27, 14, 32, 2, 0, time.UTC),
+			wantLast:  time.Date(2021, 3, 29, 11, 19, 19, 0, time.UTC),
+		},
+		{
+			name:      "article2 - default",
+			file:      "../../data/article2.csv",
+			cfg:       trip.DefaultConfig,
+			wantStops: 20,
+			wantFirst: time.Date(2020, 11, 24, 5, 2, 12, 0, time.UTC),
+			wantLast:  time.Date(2020, 12, 1, 5, 16, 45, 0, time.UTC),
+		},
+		{
+			name:      "article3 - default",
+			file:      "../../data/article3.csv",
+			cfg:       trip.DefaultConfig,
+			wantStops: 14,
+			wantFirst: time.Date(2020, 10, 27, 12, 33, 40, 0, time.UTC),
+			wantLast:  time.Date(2020, 10, 31, 18, 26, 12, 0, time.UTC),
+		},
+		{
+			name:      "article3 - one stop",
+			file:      "../../data/article3.csv",
+			cfg:       trip.Config{MaxGap: 24 * time.Hour, MaxDistance: 50},
+			wantStops: 1,
+			wantFirst: time.Date(2020, 10, 27, 12, 33, 40, 0, time.UTC),
+			wantLast:  time.Date(2020, 10, 27, 12, 33, 40, 0, time.UTC),
+		},
+		{
+			name:      "not configured",
+			file:      "../../data/article3.csv",
+			cfg:       trip.Config{},
+			wantStops: 14,
+			wantFirst: time.Date(2020, 10, 27, 12, 33, 40, 0, time.UTC),
+			wantLast:  time.Date(2020, 10, 31, 18, 26, 12, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			photoL, err := service.ReadPhotoData(context.Background(), tt.file)
+			require.NoError(t, err)
+
+			it := trip.Segment(trip.Points(photoL), tt.cfg)
+			require.Len(t, it, tt.wantStops)
+			require.Equal(t, tt.wantFirst, it[0].Start)
+			require.Equal(t, tt.wantLast, it[len(it)-1].Start)
+
+			photos := 0
+			for i, s := range it {
+				photos += len(s.PhotoIDs)
+				require.False(t, s.End.Before(s.Start))
+				if i > 0 {
+					require.False(t, s.Start.Before(it[i-1].Start), "stops not in chronological order")
+				}
+			}
+			require.Equal(t, len(trip.Points(photoL)), photos)
+		})
+	}
+}
+
+func TestPoints(t *testing.T) {
+	photoL := []photo.Data{
+		{ID: 1, Date: "2019-10-27T13:27:58Z", LatLon: photo.LatLon{Latitude: "40.647863", Longitude: "14.366958"}},
+		{ID: 2, Date: "2019-10-27 13:17:24", LatLon: photo.LatLon{Latitude: "40.648005", Longitude: "14.367228"}},
+		{ID: 3, Date: "2020-11-24T06.28:00Z", LatLon: photo.LatLon{Latitude: "36.242175", Longitude: "-116.160645"}},
+		{ID: 4, Date: "2019-10-30 19:25:32", LatLon: photo.LatLon{Latitude: "0", Longitude: "0"}},
+		{ID: 5, Date: "2019-10-30 19:25:32", LatLon: photo.LatLon{Latitude: "north", Longitude: "14.3"}},
+	}
+
+	points := trip.Points(photoL)
+	require.Len(t, points, 2)
+	require.Equal(t, 1, points[0].PhotoID)
+	require.Equal(t, 2, points[1].PhotoID)
+	require.Equal(t, time.Date(2019, 10, 27, 13, 17, 24, 0, time.UTC), points[1].Time)
+}
+
+func TestItinerary_Locate(t *testing.T) {
+	day := time.Date(2019, 10, 27, 0, 0, 0, 0, time.UTC)
+	sorrento := photo.Location{Country: "Italy", State: "Campania", City: "Sorrento"}
+	amalfi := photo.Location{Country: "Italy", State: "Campania", City: "Amalfi"}
+	naples := photo.Location{Country: "Italy", State: "Campania", City: "Naples"}
+	rome := photo.Location{Country: "Italy", State: "Lazio", City: "Rome"}
+	prague := photo.Location{Country: "Czechia", State: "Prague", City: "Prague"}
+
+	stop := func(h int, ids ...int) trip.Stop {
+		return trip.Stop{Start: day.Add(time.Duration(h) * time.Hour), End: day.Add(time.Duration(h) * time.Hour),
+			PhotoIDs: ids}
+	}
+	located := func(ls ...photo.Location) []photo.LocationM {
+		lms := []photo.LocationM{}
+		for i, l := range ls {
+			lms = append(lms, photo.LocationM{PhotoID: i + 1, Location: l})
+		}
+		return lms
+	}
+
+	tests := []struct {
+		name       string
+		itinerary  trip.Itinerary
+		locations  []photo.LocationM
+		wantCities []string
+		wantRegion string
+		wantStops  int
+	}{
+		{
+			name:       "road trip",
+			itinerary:  trip.Itinerary{stop(1, 1, 2), stop(5, 3), stop(10, 4, 5)},
+			locations:  located(sorrento, sorrento, amalfi, naples, naples),
+			wantCities: []string{"Sorrento", "Amalfi", "Naples"},
+			wantRegion: "Campania",
+			wantStops:  3,
+		},
+		{
+			name:       "consecutive stops in the same city",
+			itinerary:  trip.Itinerary{stop(1, 1), stop(5, 2), stop(10, 3)},
+			locations:  located(sorrento, sorrento, naples),
+			wantCities: []string{"Sorrento", "Naples"},
+			wantRegion: "Campania",
+			wantStops:  2,
+		},
+		{
+			name:       "most frequent stop location",
+			itinerary:  trip.Itinerary{stop(1, 1, 2, 3)},
+			locations:  located(sorrento, amalfi, sorrento),
+			wantCities: []string{"Sorrento"},
+			wantRegion: "Campania",
+			wantStops:  1,
+		},
+		{
+			name:       "different states",
+			itinerary:  trip.Itinerary{stop(1, 1), stop(5, 2)},
+			locations:  located(naples, rome),
+			wantCities: []string{"Naples", "Rome"},
+			wantRegion: "Italy",
+			wantStops:  2,
+		},
+		{
+			name:       "different countries",
+			itinerary:  trip.Itinerary{stop(1, 1), stop(5, 2)},
+			locations:  located(rome, prague),
+			wantCities: []string{"Rome", "Prague"},
+			wantRegion: "",
+			wantStops:  2,
+		},
+		{
+			name:       "stop without location",
+			itinerary:  trip.Itinerary{stop(1, 1), stop(5, 7)},
+			locations:  located(rome),
+			wantCities: []string{"Rome"},
+			wantRegion: "Lazio",
+			wantStops:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := tt.itinerary.Locate(tt.locations)
+			require.Len(t, it, tt.wantStops)
+			require.Equal(t, tt.wantCities, it.Cities())
+			require.Equal(t, tt.wantRegion, it.Region())
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Package tz resolves the timezone of a position offline, using a bundled dataset
+// of timezone boundaries queried by point-in-polygon.
+//
+// The bundled boundaries are simplified and cover the regions the articles are usually
+// about. Positions outside of them get the timezone of their country, if the country has
+// a single timezone, or keep the offset of the photo date, if it has one. Otherwise they fall
+// back to the nautical timezone of the longitude (15° wide zones without daylight saving time).
+package tz
+
+import (
+	"bytes"
+	_ "embed" // bundled timezone boundaries
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+	_ "time/tzdata" // the timezone database does not have to be installed
+)
+
+//go:embed zones.json
+var zones []byte
+
+// Resolver resolves timezones of positions.
+type Resolver struct {
+	zones []zone
+	// countries hold the timezones of the single timezone countries by ISO 3166-1 alpha-3 code.
+	countries map[string]*time.Location
+}
+
+type zone struct {
+	name     string
+	location *time.Location
+	// polygons hold the [lon, lat] boundary vertices.
+	polygons [][][2]float64
+}
+
+// dataset is the format of the timezone boundaries dataset, eg:
+//
+//	{"zones": [{"name": "Europe/Rome", "polygons": [[[lon, lat], ...]]}],
+//	 "countries": {"FRA": "Europe/Paris"}}
+type dataset struct {
+	Zones []struct {
+		Name     string         `json:"name"`
+		Polygons [][][2]float64 `json:"polygons"`
+	} `json:"zones"`
+	Countries map[string]string `json:"countries"`
+}
+
+var (
+	defaultResolver *Resolver
+	defaultOnce     sync.Once
+)
+
+// Default provides the resolver of the bundled timezone boundaries.
+func Default() *Resolver {
+	defaultOnce.Do(func() {
+		r, err := New(bytes.NewReader(zones))
+		if err != nil {
+			panic(fmt.Sprintf("invalid bundled timezone boundaries: %s", err))
+		}
+		defaultResolver = r
+	})
+	return defaultResolver
+}
+
+// New reads the timezone boundaries dataset. Zones are matched in the dataset order.
+func New(r io.Reader) (*Resolver, error) {
+	ds := dataset{}
+	if err := json.NewDecoder(r).Decode(&ds); err != nil {
+		return nil, err
+	}
+
+	res := &Resolver{countries: map[string]*time.Location{}}
+	for code, name := range ds.Countries {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		res.countries[code] = loc
+	}
+	for _, z := range ds.Zones {
+		loc, err := time.LoadLocation(z.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range z.Polygons {
+			if len(p) < 3 {
+				return nil, fmt.Errorf("zone %s: polygon with %d vertices", z.Name, len(p))
+			}
+		}
+		res.zones = append(res.zones, zone{name: z.Name, location: loc, polygons: z.Polygons})
+	}
+
+	return res, nil
+}
+
+// Location provides the timezone of the position.
+func (r *Resolver) Location(lat, lon float64) *time.Location {
+	if loc, ok := r.zone(lat, lon); ok {
+		return loc
+	}
+	return Nautical(lon)
+}
+
+// Local converts the time to the local time of the position.
+func (r *Resolver) Local(t time.Time, lat, lon float64) time.Time {
+	return r.LocalIn(t, lat, lon, "")
+}
+
+// LocalIn converts the time to the local time of the position in the country (ISO 3166-1 alpha-3 code),
+// if known. Positions outside of the zone boundaries get the timezone of the country or keep the offset
+// of the time, if it has one, before falling back to the nautical timezone of the longitude.
+func (r *Resolver) LocalIn(t time.Time, lat, lon float64, countryCode string) time.Time {
+	if loc, ok := r.zone(lat, lon); ok {
+		return t.In(loc)
+	}
+	if loc, ok := r.countries[countryCode]; ok {
+		return t.In(loc)
+	}
+	if _, offset := t.Zone(); offset != 0 {
+		return t
+	}
+	return t.In(Nautical(lon))
+}
+
+func (r *Resolver) zone(lat, lon float64) (*time.Location, bool) {
+	for _, z := range r.zones {
+		for _, p := range z.polygons {
+			if contains(p, lat, lon) {
+				return z.location, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Nautical provides the fixed timezone of the longitude, eg UTC+1 for 7.5°-22.5° east.
+func Nautical(lon float64) *time.Location {
+	offset := int(math.Round(lon / 15))
+	if offset > 12 {
+		offset = 12
+	}
+	if offset < -12 {
+		offset = -12
+	}
+
+	name := "UTC"
+	if offset != 0 {
+		name = fmt.Sprintf("UTC%+d", offset)
+	}
+	return time.FixedZone(name, offset*3600)
+}
+
+// contains is the ray casting point-in-polygon test.
+func contains(polygon [][2]float64, lat, lon float64) bool {
+	in := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		xi, yi := polygon[i][0], polygon[i][1]
+		xj, yj := polygon[j][0], polygon[j][1]
+		if (yi > lat) != (yj > lat) && lon < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			in = !in
+		}
+	}
+	return in
+}
@@ -0,0 +1,183 @@
+// +build unit_tests
+
+package tz_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tamarakaufler/travel-article-headings/internal/tz"
+)
+
+func TestResolver_Location(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     string
+	}{
+		{name: "Sorrento", lat: 40.626, lon: 14.376, want: "Europe/Rome"},
+		{name: "Cagliari", lat: 39.224, lon: 9.122, want: "Europe/Rome"},
+		{name: "Prague", lat: 50.075, lon: 14.438, want: "Europe/Prague"},
+		{name: "London", lat: 51.507, lon: -0.128, want: "Europe/London"},
+		{name: "Belfast", lat: 54.597, lon: -5.930, want: "Europe/London"},
+		{name: "New York", lat: 40.713, lon: -74.006, want: "America/New_York"},
+		{name: "Chicago", lat: 41.878, lon: -87.630, want: "America/Chicago"},
+		{name: "Denver", lat: 39.739, lon: -104.990, want: "America/Denver"},
+		{name: "Las Vegas", lat: 36.170, lon: -115.140, want: "America/Los_Angeles"},
+		{name: "Death Valley", lat: 36.242175, lon: -116.160645, want: "America/Los_Angeles"},
+		{name: "Sydney", lat: -33.869, lon: 151.209, want: "Australia/Sydney"},
+		{name: "Brisbane", lat: -27.470, lon: 153.026, want: "Australia/Brisbane"},
+		{name: "Cape Town", lat: -33.925, lon: 18.424, want: "Africa/Johannesburg"},
+
+		// boundary cases
+		{name: "Boulder City, Nevada side of the Colorado river", lat: 35.979, lon: -114.832,
+			want: "America/Los_Angeles"},
+		{name: "Kingman, Arizona side of the Colorado river", lat: 35.190, lon: -114.053,
+			want: "America/Phoenix"},
+		{name: "Coolangatta, Queensland side of the border", lat: -28.168, lon: 153.536,
+			want: "Australia/Brisbane"},
+		{name: "Tweed Heads, New South Wales side of the border", lat: -28.300, lon: 153.400,
+			want: "Australia/Sydney"},
+		{name: "Gorizia, Italian side of the border", lat: 45.941, lon: 13.622, want: "Europe/Rome"},
+
+		// longitude fallback
+		{name: "Reykjavik", lat: 64.147, lon: -21.943, want: "UTC-1"},
+		{name: "Tokyo", lat: 35.676, lon: 139.650, want: "UTC+9"},
+		{name: "Gulf of Guinea", lat: 0, lon: 0, want: "UTC"},
+		{name: "date line", lat: -17.7, lon: 179.9, want: "UTC+12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tz.Default().Location(tt.lat, tt.lon).String())
+		})
+	}
+}
+
+func TestResolver_Local(t *testing.T) {
+	tests := []struct {
+		name        string
+		utc         time.Time
+		lat, lon    float64
+		countryCode string
+		want        time.Time
+	}{
+		{
+			name: "New York the evening before",
+			utc:  time.Date(2019, 10, 27, 1, 0, 0, 0, time.UTC),
+			lat:  40.713, lon: -74.006,
+			want: time.Date(2019, 10, 26, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Sorrento summer time",
+			utc:  time.Date(2019, 7, 27, 23, 30, 0, 0, time.UTC),
+			lat:  40.626, lon: 14.376,
+			want: time.Date(2019, 7, 28, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Sorrento winter time",
+			utc:  time.Date(2019, 12, 27, 23, 30, 0, 0, time.UTC),
+			lat:  40.626, lon: 14.376,
+			want: time.Date(2019, 12, 28, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Sydney summer time",
+			utc:  time.Date(2020, 1, 10, 14, 0, 0, 0, time.UTC),
+			lat:  -33.869, lon: 151.209,
+			want: time.Date(2020, 1, 11, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Arizona without summer time",
+			utc:  time.Date(2020, 7, 10, 6, 0, 0, 0, time.UTC),
+			lat:  35.190, lon: -114.053,
+			want: time.Date(2020, 7, 9, 23, 0, 0, 0, time.UTC),
+		},
+
+		// positions outside of the zone boundaries
+		{
+			name: "Paris summer time by the country",
+			utc:  time.Date(2019, 7, 13, 21, 30, 0, 0, time.UTC),
+			lat:  48.857, lon: 2.352, countryCode: "FRA",
+			want: time.Date(2019, 7, 13, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Berlin winter time by the country",
+			utc:  time.Date(2019, 12, 31, 23, 30, 0, 0, time.UTC),
+			lat:  52.520, lon: 13.405, countryCode: "DEU",
+			want: time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Madrid summer time by the date offset",
+			utc:  time.Date(2019, 7, 13, 23, 30, 0, 0, time.FixedZone("", 2*3600)),
+			lat:  40.417, lon: -3.704,
+			want: time.Date(2019, 7, 13, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "Tenerife island zone",
+			utc:  time.Date(2019, 7, 13, 12, 0, 0, 0, time.UTC),
+			lat:  28.291, lon: -16.629, countryCode: "ESP",
+			want: time.Date(2019, 7, 13, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Tokyo by the longitude",
+			utc:  time.Date(2019, 7, 13, 12, 0, 0, 0, time.UTC),
+			lat:  35.676, lon: 139.650,
+			want: time.Date(2019, 7, 13, 21, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := tz.Default().LocalIn(tt.utc, tt.lat, tt.lon, tt.countryCode)
+			require.True(t, local.Equal(tt.utc))
+
+			// compare the wall clock
+			y, m, d := local.Date()
+			got := time.Date(y, m, d, local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			data: `{"zones": [{"name": "Europe/Rome", "polygons": [[[6.6,45.1],[7.0,45.9],[8.4,46.5]]]}]}`,
+		},
+		{
+			name:    "unknown zone",
+			data:    `{"zones": [{"name": "Europe/Sorrento", "polygons": [[[6.6,45.1],[7.0,45.9],[8.4,46.5]]]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown country zone",
+			data:    `{"zones": [], "countries": {"FRA": "Europe/Marseille"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid polygon",
+			data:    `{"zones": [{"name": "Europe/Rome", "polygons": [[[6.6,45.1],[7.0,45.9]]]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			data:    `{"zones": [`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tz.New(strings.NewReader(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
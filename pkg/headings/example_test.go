@@ -0,0 +1,104 @@
+// +build unit_tests
+
+package headings_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tamarakaufler/travel-article-headings/pkg/headings"
+)
+
+const article = `2019-10-27T13:27:58Z,40.647863,14.366958
+2019-10-27T13:17:24Z,40.648005,14.367228
+2019-10-27T13:13:09Z,40.626179,14.375734
+`
+
+// staticProviders provide the same information for all photos.
+type staticProviders struct{}
+
+func (staticProviders) Locate(ctx context.Context, p headings.Photo) (headings.Location, error) {
+	return headings.Location{Country: "Italy", City: "Sorrento"}, nil
+}
+
+func (staticProviders) Weather(ctx context.Context, p headings.Photo) (string, error) {
+	return "sunny", nil
+}
+
+func (staticProviders) PlacesOfInterest(ctx context.Context, p headings.Photo) (map[string]int, error) {
+	return map[string]int{"Restaurants": 12, "Museums": 3}, nil
+}
+
+func providers() headings.Providers {
+	return headings.Providers{
+		Location: staticProviders{},
+		Weather:  staticProviders{},
+		Poi:      staticProviders{},
+	}
+}
+
+func ExampleSuggest() {
+	photos, err := headings.ReadPhotos(strings.NewReader(article))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	res, err := headings.Suggest(context.Background(), photos,
+		headings.WithProviders(providers()),
+		headings.WithSeed(42),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(len(res.Headings))
+	fmt.Println(res.Summary.City, res.Summary.Weather, res.Summary.Weekday, res.Summary.Season)
+	fmt.Println(res.Summary.PlaceOfInterest)
+	// Output:
+	// 8
+	// Sorrento sunny Sunday Autumn
+	// Restaurants
+}
+
+func ExampleWithTemplates() {
+	photos, err := headings.ReadPhotos(strings.NewReader(article))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	res, err := headings.Suggest(context.Background(), photos,
+		headings.WithProviders(providers()),
+		headings.WithTemplates(
+			"{{.Season}} in {{.Weather}} {{.City}}",
+			"{{.City}} {{pick .ForPlaces}} {{.PlaceOfInterest}}",
+		),
+		headings.WithSeed(1),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, h := range res.Headings {
+		fmt.Println(h)
+	}
+	// Output:
+	// Autumn in sunny Sorrento
+	// Sorrento packed with Restaurants
+}
+
+func ExampleReadPhotos() {
+	photos, err := headings.ReadPhotos(strings.NewReader("2020-03-30 14:12:19,40.528808,-73.996106\n"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(photos[0].Date, photos[0].Latitude, photos[0].Longitude)
+	// Output:
+	// 2020-03-30 14:12:19 +0000 UTC 40.528808 -73.996106
+}
@@ -0,0 +1,512 @@
+// Package headings suggests travel article headings based on the article photos date,
+// latitude and longitude.
+//
+// The photos are enhanced with location, weather and places of interest information
+// by the providers, and the most frequent information is used to create the headings.
+package headings
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	"github.com/tamarakaufler/travel-article-headings/internal/holiday"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+	"github.com/tamarakaufler/travel-article-headings/internal/profile"
+	"github.com/tamarakaufler/travel-article-headings/internal/season"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
+)
+
+// articleID identifies the photos passed to the internal service.
+const articleID = "article"
+
+// ErrNoAdditionalInfo is returned when location, weather or places of interest data
+// could not be retrieved for any of the photos.
+var ErrNoAdditionalInfo = service.ErrNoAdditionalInfo
+
+// Weighting determines how much each photo counts when ranking the photo information.
+type Weighting string
+
+// Weighting strategies.
+const (
+	CountWeighting  Weighting = "count"
+	DwellWeighting  Weighting = "dwell"
+	HybridWeighting Weighting = "hybrid"
+)
+
+// SeasonCalendar is the calendar of the seasons.
+type SeasonCalendar string
+
+// Season calendars.
+const (
+	MeteorologicalSeasons SeasonCalendar = "meteorological"
+	AstronomicalSeasons   SeasonCalendar = "astronomical"
+	CustomSeasons         SeasonCalendar = "custom"
+)
+
+// HolidayCalendar holds the public holidays and festivals by ISO 3166-1 alpha-3 country code.
+type HolidayCalendar struct {
+	calendar *holiday.Calendar
+}
+
+// ReadHolidayCalendar reads the holiday calendar in JSON format. Holidays are on a fixed date,
+// relative to the Easter Sunday or on the nth weekday of a month, and can last several days, eg:
+//
+//	{"AUT": [{"name": "Christmas markets", "date": "11-15", "days": 40},
+//	         {"name": "Easter weekend", "easter": -1, "days": 3}],
+//	 "USA": [{"name": "Thanksgiving", "weekday": "Thursday", "nth": 4, "month": "November"}]}
+func ReadHolidayCalendar(r io.Reader) (*HolidayCalendar, error) {
+	c, err := holiday.New(r)
+	if err != nil {
+		return nil, err
+	}
+	return &HolidayCalendar{calendar: c}, nil
+}
+
+// PlacesBaseline holds the usual shares (0-1) of the places of interest among all places, globally
+// and by ISO 3166-1 alpha-3 country code.
+type PlacesBaseline struct {
+	baseline *poi.Baseline
+}
+
+// ReadPlacesBaseline reads the places of interest baseline in JSON format: the global shares and the shares
+// by country code. The places missing in a country are shared as globally, eg:
+//
+//	{"global": {"Restaurants": 0.3, "Pubs": 0.06, "Botanical Gardens": 0.005},
+//	 "GBR": {"Pubs": 0.18}}
+func ReadPlacesBaseline(r io.Reader) (*PlacesBaseline, error) {
+	b, err := poi.New(r)
+	if err != nil {
+		return nil, err
+	}
+	return &PlacesBaseline{baseline: b}, nil
+}
+
+// Photo holds the photo information available in the article.
+type Photo struct {
+	Date      time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// Area is a circle around the centre with the Spread (km) radius.
+type Area struct {
+	Latitude  float64
+	Longitude float64
+	Spread    float64
+}
+
+// Shares (0-1) of the photos with the summary information. A share below DominantShare
+// means the information is not dominant, eg the weather is only mostly sunny.
+type Shares struct {
+	Country         float64
+	City            float64
+	Weather         float64
+	Weekday         float64
+	Month           float64
+	Season          float64
+	TimeOfDay       float64
+	PlaceOfInterest float64
+	Holiday         float64
+	Landmark        float64
+}
+
+// DominantShare is the least share of the information to be stated flatly in the headings.
+const DominantShare = service.DominantShare
+
+// Result holds the suggested headings and the information they are based on.
+type Result struct {
+	// Headings are in the order they were selected in (see WithDiversity), with the Scores
+	// they were selected by.
+	Headings []string
+	Scores   []ScoredHeading
+	// Profiled are the Headings fitted to each of the output profiles (see WithProfiles).
+	Profiled []ProfiledHeading
+	Summary  Summary
+}
+
+// ScoredHeading is a heading with its score (0-1) and the score components: the specificity
+// and the confidence (share of the photos) of the information mentioned, the length,
+// the readability and the redundancy (repetition within the heading or of another heading).
+type ScoredHeading struct {
+	Heading string
+	Score   float64
+
+	Specificity float64
+	Confidence  float64
+	Length      float64
+	Readability float64
+	Redundancy  float64
+}
+
+// ScoreWeights determine how much each component counts in the heading score.
+type ScoreWeights struct {
+	Specificity float64
+	Confidence  float64
+	Length      float64
+	Readability float64
+	Redundancy  float64
+}
+
+// DefaultScoreWeights are the default heading score weights.
+var DefaultScoreWeights = ScoreWeights(service.DefaultScoreWeights)
+
+// Profile of the platform the headings are published on: the most characters of the heading,
+// the casing style, the banned characters and the most characters of the URL slug.
+type Profile struct {
+	Name       string
+	MaxLength  int
+	Casing     Casing
+	Banned     string
+	SlugLength int
+}
+
+// Casing style of the profile headings.
+type Casing string
+
+// Casing styles of the profiles.
+const (
+	AsIs         Casing = ""
+	TitleCase    Casing = "title"
+	SentenceCase Casing = "sentence"
+)
+
+// Builtin profiles.
+var (
+	SEOTitle          = toProfile(profile.SEOTitle)
+	OGTitle           = toProfile(profile.OGTitle)
+	Tweet             = toProfile(profile.Tweet)
+	NewsletterSubject = toProfile(profile.NewsletterSubject)
+)
+
+// ProfiledHeading is a heading fitted to the profile, with its slug and compliance: headings that
+// are too long are shortened, or dropped if they cannot be shortened.
+type ProfiledHeading struct {
+	Profile string
+	// Original is the heading as suggested.
+	Original string
+	// Heading is the heading fitted to the profile, empty if it was dropped.
+	Heading   string
+	Slug      string
+	Length    int
+	MaxLength int
+	// Compliant is true if the Original heading complies with the profile without any changes.
+	Compliant bool
+	Shortened bool
+	Dropped   bool
+	// Issues describe the changes made to fit the profile.
+	Issues []string
+}
+
+// ParseProfiles provides the builtin profiles of the comma separated names, eg "seo-title,tweet".
+func ParseProfiles(names string) ([]Profile, error) {
+	ps, err := profile.Parse(names)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]Profile, 0, len(ps))
+	for _, p := range ps {
+		profiles = append(profiles, toProfile(p))
+	}
+	return profiles, nil
+}
+
+// PhraseBook remembers the phrases of the suggested headings. Articles suggested with the same
+// phrase book (see WithPhraseBook) get headings with different phrasing.
+type PhraseBook struct {
+	book *service.PhraseBook
+}
+
+// NewPhraseBook provides an empty phrase book.
+func NewPhraseBook() *PhraseBook {
+	return &PhraseBook{book: service.NewPhraseBook()}
+}
+
+// Summary describes the most frequent photo information and the number of photos, for which
+// the information was successfully provided.
+type Summary struct {
+	Country         string
+	City            string
+	Weather         string
+	Weekday         string
+	Month           string
+	Season          string
+	TimeOfDay       string
+	PlaceOfInterest string
+	// PlacesOfInterest are the most distinctive places of interest, the PlaceOfInterest first (see WithPlaces).
+	PlacesOfInterest []string
+	// Holiday is the public holiday or festival most photos were taken on, if any.
+	Holiday string
+	// Landmark is the named landmark recurring near the photos, if any, eg Colosseum (see LandmarkProvider).
+	Landmark string
+
+	// Itinerary lists the trip stop cities in the order they were visited.
+	Itinerary []string
+	// Region is the state or country shared by all the stops.
+	Region string
+	// Focus is the area of the dominant photo cluster, if any.
+	Focus *Area
+	// Duration classifies the trip length and the days of the week it covers: day trip,
+	// weekend break, long weekend, midweek escape, week-long holiday or grand tour.
+	// Days is the number of calendar days the photos span.
+	Duration string
+	Days     int
+	// Tone is the editorial tone of the headings, if any (see WithTone).
+	Tone string
+	// Shares of the photos with the above information.
+	Shares Shares
+
+	Photos    int
+	Locations int
+	Weathers  int
+	Pois      int
+
+	Errors []string
+}
+
+// Suggest suggests headings for an article with the provided photos.
+// Providers are required. If no headings can be suggested, the Result still
+// contains the Summary. The processing is aborted with an error wrapping ErrUnauthorized
+// if a provider rejects the credentials.
+func Suggest(ctx context.Context, photos []Photo, opts ...Option) (Result, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.providers.Location == nil || o.providers.Weather == nil || o.providers.Poi == nil {
+		return Result{}, errors.New("location, weather and places of interest providers are required")
+	}
+	if len(photos) == 0 {
+		return Result{}, errors.New("no photos provided")
+	}
+
+	weighting, err := service.ParseWeighting(string(o.weighting))
+	if err != nil {
+		return Result{}, err
+	}
+	if err := tone.Validate(o.tone); err != nil {
+		return Result{}, err
+	}
+	if o.seasons.Calendar, err = season.ParseCalendar(string(o.seasons.Calendar)); err != nil {
+		return Result{}, err
+	}
+
+	catalog, err := locale.Load(o.locale)
+	if err != nil {
+		return Result{}, err
+	}
+	hg, err := service.NewLocalizedHeadingGenerator(catalog, o.templates, o.seed)
+	if err != nil {
+		return Result{}, err
+	}
+
+	pc := providerClients{providers: o.providers, photos: photos}
+	as := service.ArticleService{
+		Clients: client.Clients{
+			Addresses: pc,
+			Weather:   pc,
+			POI:       pc,
+		},
+		Generator: hg,
+		Trip:      o.trip,
+		Cluster:   o.cluster,
+		Weighting: weighting,
+		MaxDwell:  o.maxDwell,
+		Seasons:   o.seasons,
+		Holidays:  o.holidays,
+		Places:    o.places,
+		Scoring:   service.ScoreWeights(o.scoring),
+		Top:       o.top,
+		MinScore:  o.minScore,
+		Diversity: o.diversity,
+		Phrases:   o.phrases.phraseBook(),
+		Profiles:  fromProfiles(o.profiles),
+		Tone:      o.tone,
+	}
+
+	article, err := as.ProcessArticle(ctx, articleID, toPhotoData(photos))
+	for _, errM := range article.Summary.Errors {
+		o.logger.Printf("ERROR %s\n", errM)
+	}
+
+	res := Result{
+		Headings: article.Headings,
+		Scores:   toScoredHeadings(article.Scores),
+		Profiled: toProfiledHeadings(article.Profiled),
+		Summary:  toSummary(article.Summary),
+	}
+	if err != nil {
+		return res, err
+	}
+	o.logger.Printf("suggested %d headings for %d photos\n", len(res.Headings), len(photos))
+
+	return res, nil
+}
+
+// ReadPhotos reads photo records (date, latitude, longitude) in CSV format without a header.
+// The date is either in RFC3339 or "2006-01-02 15:04:05" (UTC) format.
+func ReadPhotos(r io.Reader) ([]Photo, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	photos := []Photo{}
+	for i, rec := range records {
+		if len(rec) < 3 {
+			return nil, errors.Errorf("invalid photo record %d: expected date, latitude and longitude", i+1)
+		}
+
+		p, err := parsePhoto(rec[0], rec[1], rec[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid photo record %d", i+1)
+		}
+		photos = append(photos, p)
+	}
+
+	return photos, nil
+}
+
+func parsePhoto(date, lat, lon string) (Photo, error) {
+	d, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		d, err = time.Parse("2006-01-02 15:04:05", date)
+		if err != nil {
+			return Photo{}, err
+		}
+	}
+
+	latitude, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return Photo{}, err
+	}
+	longitude, err := strconv.ParseFloat(lon, 64)
+	if err != nil {
+		return Photo{}, err
+	}
+
+	return Photo{
+		Date:      d,
+		Latitude:  latitude,
+		Longitude: longitude,
+	}, nil
+}
+
+func toSummary(s service.Summary) Summary {
+	return Summary{
+		Country:          s.Country,
+		City:             s.City,
+		Weather:          s.Weather,
+		Weekday:          s.Weekday,
+		Month:            s.Month,
+		Season:           s.Season,
+		TimeOfDay:        s.TimeOfDay,
+		PlaceOfInterest:  s.PlaceOfInterest,
+		PlacesOfInterest: s.PlacesOfInterest,
+		Holiday:          s.Holiday,
+		Landmark:         s.Landmark,
+		Itinerary:        s.Itinerary,
+		Region:           s.Region,
+		Focus:            toArea(s.Focus),
+		Duration:         s.Duration,
+		Days:             s.Days,
+		Tone:             s.Tone,
+		Shares:           Shares(s.Shares),
+		Photos:           s.Photos,
+		Locations:        s.Locations,
+		Weathers:         s.Weathers,
+		Pois:             s.Pois,
+		Errors:           s.Errors,
+	}
+}
+
+func toArea(a *service.Area) *Area {
+	if a == nil {
+		return nil
+	}
+	return &Area{Latitude: a.Latitude, Longitude: a.Longitude, Spread: a.Spread}
+}
+
+func toScoredHeadings(scored []service.ScoredHeading) []ScoredHeading {
+	if scored == nil {
+		return nil
+	}
+	headings := make([]ScoredHeading, 0, len(scored))
+	for _, sh := range scored {
+		headings = append(headings, ScoredHeading(sh))
+	}
+	return headings
+}
+
+func toProfiledHeadings(profiled []profile.Heading) []ProfiledHeading {
+	if profiled == nil {
+		return nil
+	}
+	headings := make([]ProfiledHeading, 0, len(profiled))
+	for _, ph := range profiled {
+		headings = append(headings, ProfiledHeading(ph))
+	}
+	return headings
+}
+
+func toProfile(p profile.Profile) Profile {
+	return Profile{
+		Name:       p.Name,
+		MaxLength:  p.MaxLength,
+		Casing:     Casing(p.Casing),
+		Banned:     p.Banned,
+		SlugLength: p.SlugLength,
+	}
+}
+
+func fromProfiles(profiles []Profile) []profile.Profile {
+	if profiles == nil {
+		return nil
+	}
+	ps := make([]profile.Profile, 0, len(profiles))
+	for _, p := range profiles {
+		ps = append(ps, profile.Profile{
+			Name:       p.Name,
+			MaxLength:  p.MaxLength,
+			Casing:     profile.Casing(p.Casing),
+			Banned:     p.Banned,
+			SlugLength: p.SlugLength,
+		})
+	}
+	return ps
+}
+
+func (pb *PhraseBook) phraseBook() *service.PhraseBook {
+	if pb == nil {
+		return nil
+	}
+	return pb.book
+}
+
+func toPhotoData(photos []Photo) []photo.Data {
+	photoL := []photo.Data{}
+	for i, p := range photos {
+		photoL = append(photoL, toData(i+1, p))
+	}
+	return photoL
+}
+
+func toData(id int, p Photo) photo.Data {
+	return photo.Data{
+		ArticleID: articleID,
+		ID:        id,
+		Date:      p.Date.Format(time.RFC3339),
+		LatLon: photo.LatLon{
+			Latitude:  strconv.FormatFloat(p.Latitude, 'f', -1, 64),
+			Longitude: strconv.FormatFloat(p.Longitude, 'f', -1, 64),
+		},
+	}
+}
@@ -0,0 +1,269 @@
+package headings
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/holiday"
+	"github.com/tamarakaufler/travel-article-headings/internal/locale"
+	"github.com/tamarakaufler/travel-article-headings/internal/poi"
+	"github.com/tamarakaufler/travel-article-headings/internal/season"
+	"github.com/tamarakaufler/travel-article-headings/internal/service"
+	"github.com/tamarakaufler/travel-article-headings/internal/tone"
+	"github.com/tamarakaufler/travel-article-headings/internal/trip"
+)
+
+// Option customizes Suggest.
+type Option func(*options)
+
+type options struct {
+	providers Providers
+	templates []string
+	seed      int64
+	logger    *log.Logger
+	trip      trip.Config
+	cluster   trip.ClusterConfig
+	weighting Weighting
+	maxDwell  time.Duration
+	seasons   season.Resolver
+	holidays  *holiday.Calendar
+	places    service.Places
+	locale    string
+	scoring   ScoreWeights
+	top       int
+	minScore  float64
+	diversity service.Diversity
+	phrases   *PhraseBook
+	profiles  []Profile
+	tone      string
+}
+
+func defaultOptions() options {
+	return options{
+		seed:      time.Now().UnixNano(),
+		logger:    log.New(io.Discard, "", 0),
+		trip:      trip.DefaultConfig,
+		cluster:   trip.DefaultClusterConfig,
+		weighting: HybridWeighting,
+		maxDwell:  service.DefaultMaxDwell,
+		seasons:   season.Default,
+		holidays:  holiday.Default(),
+		places:    service.Places{Baseline: poi.Default(), Distinctive: service.DefaultDistinctivePlaces},
+		locale:    locale.English,
+		scoring:   DefaultScoreWeights,
+		diversity: service.DefaultDiversity,
+	}
+}
+
+// DefaultTemplates provides the default heading templates. The templates are text/template
+// templates with access to Country, City, Weather, Weekday, Month, Season, TimeOfDay, PlaceOfInterest,
+// Holiday (empty if most photos were not taken on a holiday) and Landmark (empty if no named landmark
+// recurs near the photos) fields and to the Starts, HappyStarts, Company, ForPlaces and Adjectives vocabulary.
+// A random vocabulary phrase is chosen with the pick function, eg {{pick .Adjectives}}.
+// Trips with several stops (.Route) provide the Itinerary, Region, From and To,
+// eg {{if .Route}}From {{.From}} to {{.To}}{{end}}. The number function spells out small numbers.
+// The Duration (eg weekend break) and Days fields describe the trip length.
+// The English grammar functions realise the phrases: the (the USA), landmark (the Colosseum), locative
+// (eg {{locative .Weather .Country}}: in the sunny USA, on Capri, on the Amalfi Coast), places (plural lower
+// case places of interest, eg bars), count (eg {{count 2 "city"}}: cities) and common (lower case common
+// nouns: weekend, summer, but Sunday).
+// The templates of other locales use the grammar functions article, in and inflect
+// and the capitalize function, eg {{capitalize (in .Country)}} {{.Country}}.
+func DefaultTemplates() []string {
+	return append([]string{}, service.DefaultTemplates...)
+}
+
+// WithProviders sets the location, weather and places of interest providers.
+func WithProviders(ps Providers) Option {
+	return func(o *options) {
+		o.providers = ps
+	}
+}
+
+// WithTemplates replaces the default (or the locale) heading templates. One heading is suggested
+// for each template.
+func WithTemplates(templates ...string) Option {
+	return func(o *options) {
+		o.templates = templates
+	}
+}
+
+// WithSeed makes the choice of the template vocabulary deterministic.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithSegmentation sets the trip segmentation into stops: the longest time between two photos
+// of the same stop and the longest distance (km) of a photo from the stop centre.
+func WithSegmentation(maxGap time.Duration, maxDistance float64) Option {
+	return func(o *options) {
+		o.trip = trip.Config{MaxGap: maxGap, MaxDistance: maxDistance}
+	}
+}
+
+// WithClustering sets the density-based clustering of the photo positions: the neighbourhood
+// radius (km) of a photo and the least number of photos in a neighbourhood to form a cluster.
+// The photos are clustered within each trip stop. The location is provided once per cluster, for the photo
+// closest to the cluster centre, and the photos outside of clusters are located one by one.
+func WithClustering(eps float64, minPoints int) Option {
+	return func(o *options) {
+		o.cluster = trip.ClusterConfig{Eps: eps, MinPoints: minPoints}
+	}
+}
+
+// WithWeighting sets how much each photo counts when ranking the photo information:
+// every photo once (CountWeighting), by the time spent near the photo (DwellWeighting),
+// or the mean of both (HybridWeighting, the default). The time spent near a photo
+// is capped at maxDwell.
+func WithWeighting(w Weighting, maxDwell time.Duration) Option {
+	return func(o *options) {
+		o.weighting = w
+		o.maxDwell = maxDwell
+	}
+}
+
+// WithSeasons sets the season calendar: MeteorologicalSeasons (the default), AstronomicalSeasons
+// or CustomSeasons. The custom calendar provides the seasons by country code (Location.CountryCode,
+// eg THA) and month from the table and falls back to the meteorological calendar for other countries.
+// Seasons are flipped on the southern hemisphere and the tropics have wet and dry seasons.
+func WithSeasons(calendar SeasonCalendar, table map[string]map[time.Month]string) Option {
+	return func(o *options) {
+		o.seasons = season.Resolver{Calendar: season.Calendar(calendar), Table: table}
+	}
+}
+
+// ReadSeasonTable reads the custom seasons table (see WithSeasons) by ISO 3166-1 alpha-3 country code
+// in JSON format, eg {"THA": {"November": "Cool season", "March": "Hot season", ...}}.
+func ReadSeasonTable(r io.Reader) (map[string]map[time.Month]string, error) {
+	return season.ReadTable(r)
+}
+
+// WithHolidays extends the bundled holiday calendar with the calendar. The holidays of the calendar
+// countries replace the bundled ones.
+func WithHolidays(c *HolidayCalendar) Option {
+	return func(o *options) {
+		o.holidays = holiday.Default().Extend(c.holidayCalendar())
+	}
+}
+
+// WithPlaces sets the number of the most distinctive places of interest mentioned by the headings (2 by default),
+// eg packed with museums and botanical gardens, and extends the bundled baseline with the baseline, if provided.
+// The places of interest near the photos are distinctive, if they are more common than in the baseline
+// of the photo country.
+func WithPlaces(distinctive int, b *PlacesBaseline) Option {
+	return func(o *options) {
+		o.places = service.Places{Baseline: poi.Default().Extend(b.placesBaseline()), Distinctive: distinctive}
+	}
+}
+
+// WithLocale sets the language of the headings: en (the default), de, it or es.
+// The locale templates, vocabulary and translations of the photo information are used.
+// Pass the same locale to ProvidersFromEnv for the place names in its language.
+func WithLocale(l string) Option {
+	return func(o *options) {
+		o.locale = l
+	}
+}
+
+// Locales lists the supported locales.
+func Locales() []string {
+	return locale.Locales()
+}
+
+// WithScoring sets the weights of the heading score components.
+func WithScoring(w ScoreWeights) Option {
+	return func(o *options) {
+		o.scoring = w
+	}
+}
+
+// WithTop suggests at most n best scored headings (all if n is not positive) scoring
+// at least minScore (0-1).
+func WithTop(n int, minScore float64) Option {
+	return func(o *options) {
+		o.top = n
+		o.minScore = minScore
+	}
+}
+
+// WithDiversity sets the number of heading candidates created from each template (oversample)
+// and the trade-off (lambda 0-1) between the candidate score (1) and the dissimilarity (0)
+// to the headings already selected. By default, 4 candidates are created from each template
+// and lambda is 0.7.
+func WithDiversity(oversample int, lambda float64) Option {
+	return func(o *options) {
+		o.diversity = service.Diversity{Oversample: oversample, Lambda: lambda}
+	}
+}
+
+// WithPhraseBook avoids reusing the phrasing of the headings already suggested with the phrase book,
+// eg for the other articles of a batch. The phrases of the suggested headings are added to it.
+func WithPhraseBook(pb *PhraseBook) Option {
+	return func(o *options) {
+		o.phrases = pb
+	}
+}
+
+// WithProfiles fits the suggested headings to the output profiles, eg SEOTitle. The profile
+// casing is only applied to English headings.
+func WithProfiles(profiles ...Profile) Option {
+	return func(o *options) {
+		o.profiles = profiles
+	}
+}
+
+// AutoTone infers the tone of the headings from the places of interest (see WithTone).
+const AutoTone = tone.Auto
+
+// WithTone sets the editorial tone of the headings: family, luxury, adventure, budget, romantic
+// or nightlife, or AutoTone to infer it from the places of interest near the photos. The tone picks
+// the English heading vocabulary, prefers its places of interest, eg zoos and parks for families,
+// and leaves out the templates that do not suit it. The default vocabulary is used without a tone.
+func WithTone(name string) Option {
+	return func(o *options) {
+		o.tone = name
+	}
+}
+
+// Tones lists the builtin tones.
+func Tones() []string {
+	return tone.Names()
+}
+
+// ArticleTone reads the tone of the article (to pass to WithTone) from its sidecar metadata file,
+// eg {"tone": "family"} in article1.meta.json of article1.csv. It is empty without a sidecar file.
+func ArticleTone(articlePath string) (string, error) {
+	m, err := tone.ReadMetadata(articlePath)
+	return m.Tone, err
+}
+
+// IsMetadata is true for the paths of the article sidecar metadata files.
+func IsMetadata(path string) bool {
+	return tone.IsSidecar(path)
+}
+
+// WithLogger logs failures to retrieve photo information. Nothing is logged by default.
+func WithLogger(l *log.Logger) Option {
+	return func(o *options) {
+		if l != nil {
+			o.logger = l
+		}
+	}
+}
+
+func (c *HolidayCalendar) holidayCalendar() *holiday.Calendar {
+	if c == nil {
+		return nil
+	}
+	return c.calendar
+}
+
+func (b *PlacesBaseline) placesBaseline() *poi.Baseline {
+	if b == nil {
+		return nil
+	}
+	return b.baseline
+}
@@ -0,0 +1,199 @@
+package headings
+
+import (
+	"context"
+
+	"github.com/tamarakaufler/travel-article-headings/internal/client"
+	conf "github.com/tamarakaufler/travel-article-headings/internal/configuration"
+	"github.com/tamarakaufler/travel-article-headings/internal/photo"
+)
+
+// Kinds of provider failures. Providers can return (or wrap) them to control how the failure
+// is handled: rate limited and unavailable requests are retried, unauthorized requests abort
+// the processing and other failures skip the photo.
+var (
+	ErrRateLimited         = client.ErrRateLimited
+	ErrUnauthorized        = client.ErrUnauthorized
+	ErrNotFound            = client.ErrNotFound
+	ErrProviderUnavailable = client.ErrProviderUnavailable
+	ErrDecode              = client.ErrDecode
+	ErrResponseTooLarge    = client.ErrResponseTooLarge
+)
+
+// Location of a photo.
+type Location struct {
+	Country string
+	// CountryCode is the optional ISO 3166-1 alpha-3 country code (eg ITA). It is used
+	// to find the holidays the photos were taken on.
+	CountryCode string
+	// State (region) is optional. It is used for headings of trips with several stops.
+	State string
+	City  string
+}
+
+// LocationProvider provides the location of a photo.
+type LocationProvider interface {
+	Locate(ctx context.Context, p Photo) (Location, error)
+}
+
+// WeatherProvider provides the weather description (eg sunny) at the time and place of a photo.
+type WeatherProvider interface {
+	Weather(ctx context.Context, p Photo) (string, error)
+}
+
+// PoiProvider provides the number of places of interest of each kind (eg Restaurants) near a photo.
+type PoiProvider interface {
+	PlacesOfInterest(ctx context.Context, p Photo) (map[string]int, error)
+}
+
+// Landmark is a named place of interest near a photo, eg Colosseum.
+type Landmark struct {
+	Name string
+	// Category is the kind of place of interest, eg Museums, if known.
+	Category string
+	// Distance from the photo in meters.
+	Distance float64
+	// Popularity of the landmark, eg the number of its reviews.
+	Popularity int
+}
+
+// LandmarkProvider is a PoiProvider that also names the landmarks near a photo. The headings name
+// the landmark recurring near the photos (see Summary.Landmark) if the Poi provider is a LandmarkProvider.
+type LandmarkProvider interface {
+	PoiProvider
+	PlacesAndLandmarks(ctx context.Context, p Photo) (map[string]int, []Landmark, error)
+}
+
+// Providers enhance the photos with additional information.
+type Providers struct {
+	Location LocationProvider
+	Weather  WeatherProvider
+	Poi      PoiProvider
+}
+
+// ProvidersFromEnv provides the HERE reverse geocoding location provider and the simulated
+// weather and places of interest providers, configured through environment variables
+// (HERE_API_KEY is required). The locale (eg de) sets the language of the place names, LOCALE is used
+// if it is empty.
+func ProvidersFromEnv(locale string) (Providers, error) {
+	cfg, err := conf.Load()
+	if err != nil {
+		return Providers{}, err
+	}
+	if locale != "" {
+		cfg.Locale = locale
+	}
+
+	cs, err := client.BuildClients(cfg)
+	if err != nil {
+		return Providers{}, err
+	}
+
+	cp := clientProviders{clients: cs}
+	return Providers{
+		Location: cp,
+		Weather:  cp,
+		Poi:      cp,
+	}, nil
+}
+
+// clientProviders provide the photo information using the internal clients.
+type clientProviders struct {
+	clients client.Clients
+}
+
+func (cp clientProviders) Locate(ctx context.Context, p Photo) (Location, error) {
+	l, err := cp.clients.Addresses.Locate(ctx, toData(1, p))
+	if err != nil {
+		return Location{}, err
+	}
+
+	return Location{
+		Country:     l.Country,
+		CountryCode: l.CountryCode,
+		State:       l.State,
+		City:        l.City,
+	}, nil
+}
+
+func (cp clientProviders) Weather(ctx context.Context, p Photo) (string, error) {
+	return cp.clients.Weather.Weather(ctx, toData(1, p))
+}
+
+func (cp clientProviders) PlacesOfInterest(ctx context.Context, p Photo) (map[string]int, error) {
+	return cp.clients.POI.PlacesOfInterest(ctx, toData(1, p))
+}
+
+func (cp clientProviders) PlacesAndLandmarks(ctx context.Context, p Photo) (map[string]int, []Landmark, error) {
+	lp, ok := cp.clients.POI.(client.LandmarkPoi)
+	if !ok {
+		pois, err := cp.PlacesOfInterest(ctx, p)
+		return pois, nil, err
+	}
+
+	pois, ls, err := lp.PlacesAndLandmarks(ctx, toData(1, p))
+	if err != nil {
+		return nil, nil, err
+	}
+	landmarks := make([]Landmark, 0, len(ls))
+	for _, l := range ls {
+		landmarks = append(landmarks, Landmark(l))
+	}
+	return pois, landmarks, nil
+}
+
+// providerClients adapt the providers to the internal clients.
+type providerClients struct {
+	providers Providers
+	photos    []Photo
+}
+
+var (
+	_ client.Addresses = providerClients{}
+	_ client.Weather   = providerClients{}
+	_ client.Poi       = providerClients{}
+
+	_ client.LandmarkPoi = providerClients{}
+	_ LandmarkProvider   = clientProviders{}
+)
+
+func (pc providerClients) Locate(ctx context.Context, pd photo.Data) (photo.Location, error) {
+	l, err := pc.providers.Location.Locate(ctx, pc.photos[pd.ID-1])
+	if err != nil {
+		return photo.Location{}, err
+	}
+
+	return photo.Location{
+		Country:     l.Country,
+		CountryCode: l.CountryCode,
+		State:       l.State,
+		City:        l.City,
+	}, nil
+}
+
+func (pc providerClients) Weather(ctx context.Context, pd photo.Data) (string, error) {
+	return pc.providers.Weather.Weather(ctx, pc.photos[pd.ID-1])
+}
+
+func (pc providerClients) PlacesOfInterest(ctx context.Context, pd photo.Data) (map[string]int, error) {
+	return pc.providers.Poi.PlacesOfInterest(ctx, pc.photos[pd.ID-1])
+}
+
+func (pc providerClients) PlacesAndLandmarks(ctx context.Context, pd photo.Data,
+) (map[string]int, []photo.Landmark, error) {
+	lp, ok := pc.providers.Poi.(LandmarkProvider)
+	if !ok {
+		pois, err := pc.PlacesOfInterest(ctx, pd)
+		return pois, nil, err
+	}
+
+	pois, ls, err := lp.PlacesAndLandmarks(ctx, pc.photos[pd.ID-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	landmarks := make([]photo.Landmark, 0, len(ls))
+	for _, l := range ls {
+		landmarks = append(landmarks, photo.Landmark(l))
+	}
+	return pois, landmarks, nil
+}